@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen 熔断器处于打开状态时 CircuitBreaker.Do 返回的错误
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// 熔断器状态
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// CircuitBreaker 连续失败达到阈值后打开，冷却期内直接返回 ErrCircuitOpen
+// 冷却结束后进入半开状态，只放行一次探测调用：成功则关闭，失败则重新打开
+// 零值不可用，请使用 NewCircuitBreaker 创建；可被多个 goroutine 并发使用
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	state     string
+	openedAt  time.Time
+	probing   bool
+}
+
+// NewCircuitBreaker 创建熔断器，threshold 为触发熔断的连续失败次数，cooldown 为打开后的冷却时间
+// threshold 小于等于0时按1处理
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = 1
+	}
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown, state: CircuitClosed}
+}
+
+// Do 在熔断器允许时执行 fn 并记录结果，否则返回 ErrCircuitOpen
+func (b *CircuitBreaker) Do(fn func() error) error {
+	if !b.allow() {
+		return ErrCircuitOpen
+	}
+	completed := false
+	defer func() {
+		// fn panic 时按失败记录，避免半开状态的探测标记无法释放
+		if !completed {
+			b.record(errors.New("panic"))
+		}
+	}()
+	err := fn()
+	completed = true
+	b.record(err)
+	return err
+}
+
+// State 返回当前状态：CircuitClosed、CircuitOpen 或 CircuitHalfOpen
+func (b *CircuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.cooldown {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// Reset 手动关闭熔断器并清空失败计数
+func (b *CircuitBreaker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = CircuitClosed
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case CircuitClosed:
+		return true
+	case CircuitOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = CircuitHalfOpen
+	}
+	// 半开状态只允许一个探测调用
+	if b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitHalfOpen {
+		b.probing = false
+		if err != nil {
+			b.state = CircuitOpen
+			b.openedAt = time.Now()
+			return
+		}
+		b.state = CircuitClosed
+		b.failures = 0
+		return
+	}
+
+	if err == nil {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == CircuitClosed && b.failures >= b.threshold {
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+	}
+}
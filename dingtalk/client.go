@@ -2,25 +2,49 @@ package dingtalk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
 const (
 	ContentTypeJSON = "application/json"
+
+	// DefaultTimeout 默认HTTP请求超时时间
+	DefaultTimeout = 10 * time.Second
 )
 
+// defaultHTTPClient 默认HTTP客户端，带超时避免第三方故障时永久阻塞
+var defaultHTTPClient = &http.Client{Timeout: DefaultTimeout}
+
 // PostJSON 发送JSON格式的POST请求
 func PostJSON(url string, reqBody, respBody any) (http.Header, error) {
+	return PostJSONContext(context.Background(), nil, url, reqBody, respBody)
+}
+
+// PostJSONContext 使用指定的上下文和HTTP客户端发送JSON格式的POST请求
+// client 为 nil 时使用带默认超时的客户端
+func PostJSONContext(ctx context.Context, client *http.Client, url string, reqBody, respBody any) (http.Header, error) {
+	if client == nil {
+		client = defaultHTTPClient
+	}
+
 	body, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := http.Post(url, ContentTypeJSON, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", ContentTypeJSON)
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -1,10 +1,13 @@
 package dingtalk
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -37,6 +40,7 @@ func (t ResponseMeta) Succeed() bool {
 func NewDingtalk(accessToken string) *DingTalk {
 	return &DingTalk{
 		accessToken: accessToken,
+		httpClient:  &http.Client{Timeout: DefaultTimeout},
 	}
 }
 
@@ -46,6 +50,16 @@ func (d *DingTalk) WithSecret(secret string) *DingTalk {
 	return d
 }
 
+// WithHTTPClient 设置自定义HTTP客户端（支持链式调用）
+// 传入 nil 时恢复为带默认超时的客户端
+func (d *DingTalk) WithHTTPClient(client *http.Client) *DingTalk {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	d.httpClient = client
+	return d
+}
+
 // ValidateMsgType 验证消息类型
 func ValidateMsgType(v string) error {
 	switch v {
@@ -74,6 +88,12 @@ type Message struct {
 // 每个机器人每分钟最多发送20条消息到群里，如果超过20条，会限流10分钟
 // 如果你有大量发消息的场景（譬如系统监控报警）可以将这些信息进行整合，通过markdown消息以摘要的形式发送到群里。
 func (d *DingTalk) Send(msg *Message) error {
+	return d.SendContext(context.Background(), msg)
+}
+
+// SendContext 使用指定上下文发送钉钉自定义机器人消息
+// 上下文取消或超时后请求立即返回
+func (d *DingTalk) SendContext(ctx context.Context, msg *Message) error {
 	u := sendURL + url.QueryEscape(d.accessToken)
 	if d.secret != "" {
 		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
@@ -84,7 +104,7 @@ func (d *DingTalk) Send(msg *Message) error {
 		u = u + "&timestamp=" + timestamp + "&sign=" + url.QueryEscape(sign)
 	}
 	var resp ResponseMeta
-	headers, err := PostJSON(u, msg, &resp)
+	headers, err := PostJSONContext(ctx, d.httpClient, u, msg, &resp)
 	if err != nil {
 		if headers == nil {
 			return err
@@ -122,6 +142,34 @@ func (d *DingTalk) SendMarkdown(title, text string, at *AtMeta) error {
 	return d.Send(msg)
 }
 
+// SendMarkdownAt 发送markdown消息，并自动在正文末尾追加 @手机号/@userid
+// 钉钉要求markdown正文中包含 @xxx 才会渲染提醒效果
+func (d *DingTalk) SendMarkdownAt(title, text string, at *AtMeta) error {
+	return d.SendMarkdown(title, appendAtTokens(text, at), at)
+}
+
+// appendAtTokens 将@对象追加到markdown正文，已存在的不重复追加
+func appendAtTokens(text string, at *AtMeta) string {
+	if at == nil {
+		return text
+	}
+	var tokens []string
+	for _, v := range append(append([]string{}, at.AtMobiles...), at.AtUserIds...) {
+		if v == "" {
+			continue
+		}
+		token := "@" + v
+		if strings.Contains(text, token) {
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+	if len(tokens) == 0 {
+		return text
+	}
+	return text + "\n\n" + strings.Join(tokens, " ")
+}
+
 // SendLink 发送链接消息（支持链式调用）
 func (d *DingTalk) SendLink(link *LinkMeta) error {
 	msg := &Message{
@@ -0,0 +1,87 @@
+package dingtalk
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// redirectTransport 将所有请求转发到测试服务器
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (rt redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestSendMarkdownAt(t *testing.T) {
+	var body []byte
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer srv.Close()
+
+	target, _ := url.Parse(srv.URL)
+	d := NewDingtalk("token").WithHTTPClient(&http.Client{Transport: redirectTransport{target}})
+
+	at := &AtMeta{AtMobiles: []string{"13800000000"}, AtUserIds: []string{"user1", "user2"}}
+	if err := d.SendMarkdownAt("告警", "磁盘空间不足 @user2", at); err != nil {
+		t.Fatal(err)
+	}
+
+	if query.Get("access_token") != "token" {
+		t.Errorf("access_token = %q", query.Get("access_token"))
+	}
+
+	var msg struct {
+		MsgType  string `json:"msgtype"`
+		Markdown struct {
+			Title string `json:"title"`
+			Text  string `json:"text"`
+		} `json:"markdown"`
+		At struct {
+			AtMobiles []string `json:"atMobiles"`
+			AtUserIds []string `json:"atUserIds"`
+		} `json:"at"`
+	}
+	if err := json.Unmarshal(body, &msg); err != nil {
+		t.Fatalf("decode request body %s: %v", body, err)
+	}
+	if msg.MsgType != MsgTypeMarkdown || msg.Markdown.Title != "告警" {
+		t.Errorf("msgtype = %q, title = %q", msg.MsgType, msg.Markdown.Title)
+	}
+	if strings.Join(msg.At.AtMobiles, ",") != "13800000000" || strings.Join(msg.At.AtUserIds, ",") != "user1,user2" {
+		t.Errorf("at = %+v", msg.At)
+	}
+	// 已存在的 @user2 不重复追加
+	if want := "磁盘空间不足 @user2\n\n@13800000000 @user1"; msg.Markdown.Text != want {
+		t.Errorf("text = %q, want %q", msg.Markdown.Text, want)
+	}
+}
+
+func TestSendContextError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"errcode":310000,"errmsg":"keywords not in content"}`))
+	}))
+	defer srv.Close()
+
+	target, _ := url.Parse(srv.URL)
+	d := NewDingtalk("token").WithHTTPClient(&http.Client{Transport: redirectTransport{target}})
+	err := d.SendText("hello", nil)
+	if err == nil || !strings.Contains(err.Error(), "310000") {
+		t.Fatalf("err = %v, want errcode 310000", err)
+	}
+}
@@ -1,5 +1,7 @@
 package dingtalk
 
+import "net/http"
+
 // TextMeta 文本消息
 type TextMeta struct {
 	Content string `json:"content"` // 消息内容
@@ -71,4 +73,5 @@ type ResponseMeta struct {
 type DingTalk struct {
 	accessToken string
 	secret      string
+	httpClient  *http.Client
 }
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
@@ -42,6 +43,56 @@ func UUID() string {
 	return uuid.String()
 }
 
+const (
+	idAlphabet           = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	clientOrderIDMaxLen  = 36
+	clientOrderIDMinRand = 16
+)
+
+// ShortID 生成长度为 n 的随机 ID，字符集为 [0-9A-Za-z]，随机源为 crypto/rand
+func ShortID(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	// 256 不能被 62 整除，丢弃 >= 248 的字节以避免取模偏差
+	const limit = 256 - 256%len(idAlphabet)
+	out := make([]byte, 0, n)
+	buf := make([]byte, n+n/4+1)
+	for len(out) < n {
+		rand.Read(buf)
+		for _, b := range buf {
+			if int(b) >= limit {
+				continue
+			}
+			out = append(out, idAlphabet[int(b)%len(idAlphabet)])
+			if len(out) == n {
+				break
+			}
+		}
+	}
+	return string(out)
+}
+
+// NewClientOrderID 生成符合交易所 newClientOrderId 规则（[.A-Za-z0-9:/_-]，最长 36 位）的随机订单 ID
+// prefix 中不合法的字符会被丢弃，且最多保留 19 位，以保证至少 16 位随机部分
+func NewClientOrderID(prefix string) string {
+	clean := make([]byte, 0, len(prefix))
+	for i := 0; i < len(prefix); i++ {
+		c := prefix[i]
+		if c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' ||
+			c == '.' || c == ':' || c == '/' || c == '_' || c == '-' {
+			clean = append(clean, c)
+		}
+	}
+	if len(clean) == 0 {
+		return ShortID(clientOrderIDMaxLen)
+	}
+	if maxPrefix := clientOrderIDMaxLen - clientOrderIDMinRand - 1; len(clean) > maxPrefix {
+		clean = clean[:maxPrefix]
+	}
+	return string(clean) + "_" + ShortID(clientOrderIDMaxLen-len(clean)-1)
+}
+
 // Sign 生成签名
 // 使用 HMAC-SHA256
 func Sign(appKey string, data ...interface{}) string {
@@ -1,11 +1,14 @@
 package utils
 
 import (
-	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -439,6 +442,8 @@ func (u UnknownType) ToUnsafePointer() interface{} {
 // --------------------------------------------------------------------
 
 // unmarshalWithTypeConversion 根据目标类型进行智能转换
+// 字段的 ,string 选项与 encoding/json 一致：字符串字段会去掉内层引号，数字、布尔字段从字符串解析；
+// 不同的是原始值类型不符时不会报错，而是按目标类型尽量转换
 func (u UnknownType) SmartUnmarshal(v interface{}) error {
 	data := u.Value
 
@@ -452,6 +457,56 @@ func (u UnknownType) SmartUnmarshal(v interface{}) error {
 	return setValue(rv.Elem(), data)
 }
 
+// SmartDecodeStream 流式解码JSON Lines数据
+// 说明：
+//
+//	逐个读取输入中的JSON值（通常每行一个），使用与 SmartUnmarshal 相同的类型转换规则填充到 newElem 返回的对象中，
+//	再交给 handle 处理，内存占用与单条数据大小相关而与文件大小无关
+//	空行会被忽略；handle 返回错误时立即停止并返回该错误
+//
+// 参数：
+//   - r: 数据来源
+//   - newElem: 为每条数据创建目标对象，必须返回非空指针
+//   - handle: 处理填充后的对象
+//
+// 返回值：
+//   - error: 解析、转换或处理过程中的错误
+//
+// 示例：
+//
+//	err := utils.SmartDecodeStream(file, func() interface{} { return &Kline{} }, func(v interface{}) error {
+//		klines = append(klines, v.(*Kline))
+//		return nil
+//	})
+func SmartDecodeStream(r io.Reader, newElem func() interface{}, handle func(interface{}) error) error {
+	if newElem == nil || handle == nil {
+		return fmt.Errorf("newElem 和 handle 不能为空")
+	}
+
+	decoder := json.NewDecoder(r)
+	for index := 1; ; index++ {
+		var data interface{}
+		if err := decoder.Decode(&data); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("解析第%d条数据失败: %v", index, err)
+		}
+
+		elem := newElem()
+		rv := reflect.ValueOf(elem)
+		if rv.Kind() != reflect.Ptr || rv.IsNil() {
+			return fmt.Errorf("目标必须是非空指针")
+		}
+		if err := setValue(rv.Elem(), data); err != nil {
+			return fmt.Errorf("转换第%d条数据失败: %v", index, err)
+		}
+		if err := handle(elem); err != nil {
+			return err
+		}
+	}
+}
+
 // fillStruct 递归填充结构体
 func fillStruct(target reflect.Value, data interface{}) error {
 	if !target.CanSet() {
@@ -460,7 +515,7 @@ func fillStruct(target reflect.Value, data interface{}) error {
 
 	switch target.Kind() {
 	case reflect.Struct:
-		dataMap, ok := data.(map[string]interface{})
+		dataMap, ok := asStringMap(data)
 		if !ok {
 			return nil
 		}
@@ -476,18 +531,13 @@ func fillStruct(target reflect.Value, data interface{}) error {
 			}
 
 			// 获取JSON标签
-			jsonTag := fieldType.Tag.Get("json")
-			if jsonTag == "" || jsonTag == "-" {
-				jsonTag = fieldType.Name
-			} else {
-				// 处理json标签的逗号分隔(如 `json:"name,omitempty"`)
-				if idx := bytes.IndexByte([]byte(jsonTag), ','); idx != -1 {
-					jsonTag = jsonTag[:idx]
-				}
-			}
+			jsonTag, asString := parseJSONTag(fieldType)
 
 			// 获取对应的数据
 			if rawValue, exists := dataMap[jsonTag]; exists {
+				if asString {
+					rawValue = unquoteStringOption(field.Type(), rawValue)
+				}
 				if err := setValue(field, rawValue); err != nil {
 					return err
 				}
@@ -495,7 +545,7 @@ func fillStruct(target reflect.Value, data interface{}) error {
 		}
 
 	case reflect.Slice:
-		dataSlice, ok := data.([]interface{})
+		dataSlice, ok := asSlice(data)
 		if !ok {
 			return nil
 		}
@@ -509,18 +559,23 @@ func fillStruct(target reflect.Value, data interface{}) error {
 		target.Set(slice)
 
 	case reflect.Map:
-		dataMap, ok := data.(map[string]interface{})
+		dataMap, ok := asStringMap(data)
 		if !ok {
 			return nil
 		}
 
-		mapValue := reflect.MakeMap(target.Type())
+		// 元素为结构体或结构体指针时，setValue 会继续递归到 fillStruct
+		mapType := target.Type()
+		mapValue := reflect.MakeMapWithSize(mapType, len(dataMap))
 		for key, value := range dataMap {
-			keyValue := reflect.ValueOf(key)
-			elemValue := reflect.New(target.Type().Elem()).Elem()
-			if err := setValue(elemValue, value); err != nil {
+			keyValue, err := mapKey(mapType.Key(), key)
+			if err != nil {
 				return err
 			}
+			elemValue := reflect.New(mapType.Elem()).Elem()
+			if err := setValue(elemValue, value); err != nil {
+				return fmt.Errorf("键 %q: %w", key, err)
+			}
 			mapValue.SetMapIndex(keyValue, elemValue)
 		}
 		target.Set(mapValue)
@@ -529,13 +584,120 @@ func fillStruct(target reflect.Value, data interface{}) error {
 	return nil
 }
 
+// asStringMap 将数据转换为 map[string]interface{}
+// 除 JSON 解码得到的 map[string]interface{} 外，也支持 map[string]string、map[string]any 等键为字符串类型的任意map
+func asStringMap(data interface{}) (map[string]interface{}, bool) {
+	if dataMap, ok := data.(map[string]interface{}); ok {
+		return dataMap, true
+	}
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	dataMap := make(map[string]interface{}, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		dataMap[iter.Key().String()] = iter.Value().Interface()
+	}
+	return dataMap, true
+}
+
+// asSlice 将数据转换为 []interface{}，支持任意元素类型的切片与数组
+func asSlice(data interface{}) ([]interface{}, bool) {
+	if dataSlice, ok := data.([]interface{}); ok {
+		return dataSlice, true
+	}
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	dataSlice := make([]interface{}, rv.Len())
+	for i := range dataSlice {
+		dataSlice[i] = rv.Index(i).Interface()
+	}
+	return dataSlice, true
+}
+
+// mapKey 将JSON对象的键转换为目标map的键类型
+// 与 encoding/json 一致，支持字符串类型（包括自定义的字符串类型）以及整数类型的键
+func mapKey(keyType reflect.Type, key string) (reflect.Value, error) {
+	keyValue := reflect.New(keyType).Elem()
+	switch keyType.Kind() {
+	case reflect.String:
+		keyValue.SetString(key)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(key, 10, keyType.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("无法将键 %q 转换为 %s", key, keyType)
+		}
+		keyValue.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(key, 10, keyType.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("无法将键 %q 转换为 %s", key, keyType)
+		}
+		keyValue.SetUint(n)
+	default:
+		return reflect.Value{}, fmt.Errorf("不支持的map键类型: %s", keyType)
+	}
+	return keyValue, nil
+}
+
+// parseJSONTag 解析字段的json标签，返回数据中的键名以及是否带有 ,string 选项
+// 未设置标签、标签为"-"或名称为空（如 `json:",string"`）时使用字段名
+func parseJSONTag(field reflect.StructField) (name string, asString bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" || tag == "-" {
+		return field.Name, false
+	}
+
+	// 处理json标签的逗号分隔(如 `json:"name,omitempty"`)
+	name, opts, _ := strings.Cut(tag, ",")
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == "string" {
+			asString = true
+		}
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, asString
+}
+
+// unquoteStringOption 处理 ,string 选项
+// 说明：
+//
+//	encoding/json 中 ,string 表示值被再编码为JSON字符串：数字、布尔字段接收 "123"、"true"，
+//	字符串字段接收 "\"abc\""，解码时需要先去掉一层引号
+//	数字、布尔字段本身就会从字符串转换，这里只需为字符串字段去掉内层引号
+//	与标准库的差异：标准库遇到非字符串的原始值（如字符串字段收到JSON数字123）会报错，
+//	这里保持智能转换的宽松行为，原样交给后续的类型转换
+func unquoteStringOption(fieldType reflect.Type, rawValue interface{}) interface{} {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.String {
+		return rawValue
+	}
+	str, ok := rawValue.(string)
+	if !ok || !strings.HasPrefix(str, `"`) {
+		return rawValue
+	}
+	if unquoted, err := strconv.Unquote(str); err == nil {
+		return unquoted
+	}
+	return rawValue
+}
+
 // fillArray 填充数组
 func fillArray(target reflect.Value, data interface{}) error {
 	if !target.CanSet() {
 		return nil
 	}
 
-	dataSlice, ok := data.([]interface{})
+	dataSlice, ok := asSlice(data)
 	if !ok {
 		return nil
 	}
@@ -555,21 +717,44 @@ func fillArray(target reflect.Value, data interface{}) error {
 	return nil
 }
 
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
 // handleTimeStruct 处理 time.Time 结构
+// 说明：
+//
+//	字符串按 RFC3339、RFC3339Nano 等常见格式解析，纯数字字符串与数字按unix时间戳处理
+//	时间戳单位按数量级判断：秒、毫秒、微秒、纳秒，带小数的时间戳保留小数部分
+//	空字符串保持零值，无法解析的字符串返回错误
 func handleTimeStruct(target reflect.Value, data interface{}) error {
-	// 尝试解析各种时间格式
 	var timeStr string
 	switch v := data.(type) {
 	case string:
 		timeStr = v
 	case json.Number:
 		timeStr = v.String()
+	case time.Time:
+		target.Set(reflect.ValueOf(v))
+		return nil
+	case float32, float64:
+		target.Set(reflect.ValueOf(unixFloatToTime(NewUnknownType(v).ToFloat64())))
+		return nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		target.Set(reflect.ValueOf(unixToTime(NewUnknownType(v).ToInt64())))
+		return nil
 	default:
 		return nil
 	}
 
+	if timeStr == "" {
+		return nil
+	}
+
 	// 尝试多种时间格式解析
 	timeFormats := []string{
+		time.RFC3339Nano,
 		time.RFC3339,
 		"2006-01-02T15:04:05Z",
 		"2006-01-02T15:04:05.999Z",
@@ -584,6 +769,86 @@ func handleTimeStruct(target reflect.Value, data interface{}) error {
 		}
 	}
 
+	// 数字字符串按时间戳处理
+	if ts, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
+		target.Set(reflect.ValueOf(unixToTime(ts)))
+		return nil
+	}
+	if ts, err := strconv.ParseFloat(timeStr, 64); err == nil {
+		target.Set(reflect.ValueOf(unixFloatToTime(ts)))
+		return nil
+	}
+
+	return fmt.Errorf("无法解析时间: %s", timeStr)
+}
+
+// unixToTime 按数量级将时间戳转换为时间（秒、毫秒、微秒、纳秒）
+func unixToTime(ts int64) time.Time {
+	switch unixUnit(ts) {
+	case time.Nanosecond:
+		return time.Unix(0, ts)
+	case time.Microsecond:
+		return time.UnixMicro(ts)
+	case time.Millisecond:
+		return time.UnixMilli(ts)
+	default:
+		return time.Unix(ts, 0)
+	}
+}
+
+// unixFloatToTime 按数量级将带小数的时间戳转换为时间，小数部分按所属单位换算为纳秒，不会被截断
+// 如 1700000000.25 秒、1700000000250.5 毫秒
+func unixFloatToTime(ts float64) time.Time {
+	whole, frac := math.Modf(ts)
+	unit := unixUnit(int64(whole))
+	return unixToTime(int64(whole)).Add(time.Duration(math.Round(frac * float64(unit))))
+}
+
+// unixUnit 按时间戳的数量级判断单位
+func unixUnit(ts int64) time.Duration {
+	if ts < 0 {
+		ts = -ts
+	}
+	switch {
+	case ts >= 1e17:
+		return time.Nanosecond
+	case ts >= 1e14:
+		return time.Microsecond
+	case ts >= 1e11:
+		return time.Millisecond
+	default:
+		return time.Second
+	}
+}
+
+// handleDuration 处理 time.Duration
+// 说明：
+//
+//	数字按纳秒处理，字符串优先按 time.ParseDuration 解析（如 "1m30s"），纯数字字符串按纳秒处理
+func handleDuration(target reflect.Value, data interface{}) error {
+	if str, ok := data.(string); ok {
+		if str == "" {
+			return nil
+		}
+		if d, err := time.ParseDuration(str); err == nil {
+			target.SetInt(int64(d))
+			return nil
+		}
+		if n, err := strconv.ParseInt(str, 10, 64); err == nil {
+			target.SetInt(n)
+			return nil
+		}
+		return fmt.Errorf("无法解析时长: %s", str)
+	}
+	if num, ok := data.(json.Number); ok {
+		n, err := num.Int64()
+		if err != nil {
+			return fmt.Errorf("无法解析时长: %s", num)
+		}
+		target.SetInt(n)
+		return nil
+	}
+	target.SetInt(NewUnknownType(data).ToInt64())
 	return nil
 }
 
@@ -598,6 +863,14 @@ func setValue(target reflect.Value, data interface{}) error {
 		return nil
 	}
 
+	// time.Time 和 time.Duration 需要在通用处理之前特殊处理
+	switch target.Type() {
+	case timeType:
+		return handleTimeStruct(target, data)
+	case durationType:
+		return handleDuration(target, data)
+	}
+
 	targetKind := target.Kind()
 
 	// 使用 UnknownType 进行智能类型转换
@@ -662,10 +935,6 @@ func setValue(target reflect.Value, data interface{}) error {
 		return setValue(target.Elem(), data)
 
 	case reflect.Struct:
-		// 尝试处理 time.Time
-		if target.Type().String() == "time.Time" {
-			return handleTimeStruct(target, data)
-		}
 		return fillStruct(target, data)
 
 	case reflect.Slice:
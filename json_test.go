@@ -0,0 +1,198 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type testItem struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+func decodeJSON(t *testing.T, s string) UnknownType {
+	t.Helper()
+	var data interface{}
+	if err := json.Unmarshal([]byte(s), &data); err != nil {
+		t.Fatal(err)
+	}
+	return NewUnknownType(data)
+}
+
+func TestSmartUnmarshalMapOfStruct(t *testing.T) {
+	u := decodeJSON(t, `{"btc":{"name":"BTC","price":"65000.5"},"eth":{"name":"ETH","price":3000}}`)
+
+	var values map[string]testItem
+	if err := u.SmartUnmarshal(&values); err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 2 || values["btc"].Price != 65000.5 || values["eth"].Name != "ETH" {
+		t.Fatalf("map[string]testItem = %+v", values)
+	}
+
+	var pointers map[string]*testItem
+	if err := u.SmartUnmarshal(&pointers); err != nil {
+		t.Fatal(err)
+	}
+	if len(pointers) != 2 || pointers["btc"] == nil || pointers["btc"].Price != 65000.5 || pointers["eth"].Price != 3000 {
+		t.Fatalf("map[string]*testItem = %+v", pointers)
+	}
+}
+
+func TestSmartUnmarshalMapKeys(t *testing.T) {
+	u := decodeJSON(t, `{"1":"one","-2":"minus two"}`)
+	var ints map[int]string
+	if err := u.SmartUnmarshal(&ints); err != nil {
+		t.Fatal(err)
+	}
+	if ints[1] != "one" || ints[-2] != "minus two" {
+		t.Fatalf("map[int]string = %v", ints)
+	}
+
+	type symbol string
+	var named map[symbol]string
+	if err := u.SmartUnmarshal(&named); err != nil {
+		t.Fatal(err)
+	}
+	if named["1"] != "one" {
+		t.Fatalf("map[symbol]string = %v", named)
+	}
+
+	var uints map[uint8]string
+	if err := decodeJSON(t, `{"300":"overflow"}`).SmartUnmarshal(&uints); err == nil {
+		t.Fatal("expected error for out of range uint8 key")
+	}
+}
+
+func TestSmartUnmarshalNonJSONMap(t *testing.T) {
+	// 代码中直接构造的map，值为Go原生类型而不是JSON解码得到的float64
+	data := map[string]interface{}{
+		"btc": map[string]interface{}{"name": "BTC", "price": 65000},
+		"eth": map[string]string{"name": "ETH", "price": "3000.25"},
+	}
+	var got map[string]*testItem
+	if err := NewUnknownType(data).SmartUnmarshal(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got["btc"].Price != 65000 || got["eth"].Name != "ETH" || got["eth"].Price != 3000.25 {
+		t.Fatalf("got %+v %+v", got["btc"], got["eth"])
+	}
+
+	var list []testItem
+	items := []map[string]interface{}{{"name": "A", "price": int64(1)}, {"name": "B", "price": float32(2.5)}}
+	if err := NewUnknownType(items).SmartUnmarshal(&list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Price != 1 || list[1].Price != 2.5 {
+		t.Fatalf("list = %+v", list)
+	}
+}
+
+func TestSmartUnmarshalTime(t *testing.T) {
+	type target struct {
+		At time.Time `json:"at"`
+	}
+	base := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC) // unix 1700000000
+	cases := []struct {
+		name string
+		data interface{}
+		want time.Time
+	}{
+		{"RFC3339", "2023-11-14T22:13:20Z", base},
+		{"RFC3339 offset", "2023-11-15T06:13:20+08:00", base},
+		{"RFC3339Nano", "2023-11-14T22:13:20.123456789Z", base.Add(123456789)},
+		{"unix seconds", 1700000000, base},
+		{"unix seconds float64", float64(1700000000), base},
+		{"unix seconds fraction", 1700000000.25, base.Add(250 * time.Millisecond)},
+		{"unix seconds negative fraction", -0.5, time.Unix(0, 0).Add(-500 * time.Millisecond)},
+		{"unix millis", int64(1700000000123), base.Add(123 * time.Millisecond)},
+		{"unix millis fraction", 1700000000123.5, base.Add(123*time.Millisecond + 500*time.Microsecond)},
+		{"unix micros", int64(1700000000123456), base.Add(123456 * time.Microsecond)},
+		{"unix nanos", int64(1700000000123456789), base.Add(123456789)},
+		{"numeric string", "1700000000", base},
+		{"float string", "1700000000.25", base.Add(250 * time.Millisecond)},
+		{"json.Number", json.Number("1700000000123"), base.Add(123 * time.Millisecond)},
+	}
+	for _, c := range cases {
+		var got target
+		if err := NewUnknownType(map[string]interface{}{"at": c.data}).SmartUnmarshal(&got); err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if !got.At.Equal(c.want) {
+			t.Errorf("%s: got %s, want %s", c.name, got.At.UTC().Format(time.RFC3339Nano), c.want.Format(time.RFC3339Nano))
+		}
+	}
+
+	var empty target
+	if err := NewUnknownType(map[string]interface{}{"at": ""}).SmartUnmarshal(&empty); err != nil || !empty.At.IsZero() {
+		t.Errorf("empty string: err = %v, time = %s", err, empty.At)
+	}
+	var invalid target
+	if err := NewUnknownType(map[string]interface{}{"at": "yesterday"}).SmartUnmarshal(&invalid); err == nil {
+		t.Error("expected error for unparseable time")
+	}
+}
+
+func TestSmartUnmarshalDuration(t *testing.T) {
+	type target struct {
+		Timeout time.Duration `json:"timeout"`
+	}
+	cases := []struct {
+		name string
+		data interface{}
+		want time.Duration
+	}{
+		{"duration string", "1m30s", 90 * time.Second},
+		{"duration string ms", "250ms", 250 * time.Millisecond},
+		{"nanoseconds number", float64(1500000000), 1500 * time.Millisecond},
+		{"nanoseconds int", 42, 42},
+		{"nanoseconds string", "1000", 1000},
+		{"json.Number", json.Number("2000000000"), 2 * time.Second},
+	}
+	for _, c := range cases {
+		var got target
+		if err := NewUnknownType(map[string]interface{}{"timeout": c.data}).SmartUnmarshal(&got); err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if got.Timeout != c.want {
+			t.Errorf("%s: got %s, want %s", c.name, got.Timeout, c.want)
+		}
+	}
+
+	var invalid target
+	if err := NewUnknownType(map[string]interface{}{"timeout": "soon"}).SmartUnmarshal(&invalid); err == nil {
+		t.Error("expected error for unparseable duration")
+	}
+}
+
+func TestSmartUnmarshalStringOption(t *testing.T) {
+	type target struct {
+		ID     int64   `json:"id,string"`
+		Enable bool    `json:"enable,string"`
+		Price  float64 `json:"price,string"`
+		Note   string  `json:"note,string"`
+		Count  *int    `json:",string"`
+	}
+
+	quoted := decodeJSON(t, `{"id":"123","enable":"true","price":"1.5","note":"\"abc\"","Count":"7"}`)
+	var got target
+	if err := quoted.SmartUnmarshal(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != 123 || !got.Enable || got.Price != 1.5 || got.Note != "abc" || got.Count == nil || *got.Count != 7 {
+		t.Fatalf("quoted: %+v", got)
+	}
+
+	// 与 encoding/json 不同，未加引号的原始值同样接受
+	plain := decodeJSON(t, `{"id":123,"enable":true,"price":1.5,"note":"abc","Count":7}`)
+	got = target{}
+	if err := plain.SmartUnmarshal(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != 123 || !got.Enable || got.Price != 1.5 || got.Note != "abc" || got.Count == nil || *got.Count != 7 {
+		t.Fatalf("plain: %+v", got)
+	}
+}
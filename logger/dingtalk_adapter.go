@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/phrynus/go-utils/dingtalk"
+)
+
+// dingTalkHookQueueSize 钉钉钩子待发送队列的容量
+const dingTalkHookQueueSize = 100
+
+// dingTalkHookFatalWait ERROR级别日志等待发送完成的最长时间，超过后不再等待以免拖住程序退出
+const dingTalkHookFatalWait = 5 * time.Second
+
+// NewDingTalkHook 创建钉钉日志钩子
+// 说明：
+//
+//	将日志条目格式化为markdown消息，交给独立的goroutine通过钉钉机器人发送
+//	钩子只把消息放入容量为100的队列后立即返回，不会因网络请求阻塞日志写入
+//	队列已满时丢弃该条消息并打印到标准输出
+//	ERROR级别日志会在退出前等待本条消息发送完成，最多等待5秒
+//	发送失败时仅打印到标准输出，不会回调logger，避免循环
+//
+// 参数：
+//   - dt: 钉钉客户端
+//   - at: @配置，可为nil
+//
+// 返回值：
+//   - Hook: 日志钩子函数
+func NewDingTalkHook(dt *dingtalk.DingTalk, at *dingtalk.AtMeta) Hook {
+	return newAsyncHook(dingTalkHookQueueSize, dingTalkHookFatalWait, func(entry LogEntry) {
+		title := fmt.Sprintf("[%s] %s", entry.Prefix, levelName(entry.Level))
+
+		var text strings.Builder
+		text.WriteString("### ")
+		text.WriteString(title)
+		text.WriteString("\n\n- **时间**: ")
+		text.WriteString(entry.Time.Format("2006/01/02 15:04:05.000"))
+		if entry.FileLine != "" {
+			text.WriteString("\n- **位置**: ")
+			text.WriteString(entry.FileLine)
+		}
+		text.WriteString("\n\n> ")
+		text.WriteString(strings.ReplaceAll(strings.TrimRight(entry.Message, "\n"), "\n", "\n> "))
+
+		if err := dt.SendMarkdownAt(title, text.String(), at); err != nil {
+			fmt.Printf("dingtalk hook send failed: %v\n", err)
+		}
+	})
+}
+
+// asyncHookItem 异步钩子队列中的条目，done 非nil时发送完成后关闭
+type asyncHookItem struct {
+	entry LogEntry
+	done  chan struct{}
+}
+
+// newAsyncHook 创建在独立goroutine中执行 send 的钩子
+// 队列满时丢弃条目；ERROR级别条目最多等待 fatalWait 让其在程序退出前发送完成
+func newAsyncHook(size int, fatalWait time.Duration, send func(entry LogEntry)) Hook {
+	queue := make(chan asyncHookItem, size)
+	go func() {
+		for item := range queue {
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						fmt.Printf("log hook panic: %v\n", r)
+					}
+					if item.done != nil {
+						close(item.done)
+					}
+				}()
+				send(item.entry)
+			}()
+		}
+	}()
+
+	return func(entry LogEntry) {
+		item := asyncHookItem{entry: entry}
+		if entry.Level == ERROR {
+			item.done = make(chan struct{})
+		}
+		select {
+		case queue <- item:
+		default:
+			fmt.Printf("log hook queue full, entry dropped: %s\n", entry.Message)
+			return
+		}
+		if item.done != nil {
+			select {
+			case <-item.done:
+			case <-time.After(fatalWait):
+			}
+		}
+	}
+}
+
+// AddDingTalkHook 注册钉钉日志钩子
+// 说明：
+//
+//	将指定级别的日志推送到钉钉，未指定级别时默认只推送ERROR级别
+//
+// 示例：
+//
+//	dt := dingtalk.NewDingtalk("token").WithSecret("secret")
+//	myLogger.AddDingTalkHook(dt, &dingtalk.AtMeta{IsAtAll: true}, logger.ERROR, logger.WARN)
+func (l *Logger) AddDingTalkHook(dt *dingtalk.DingTalk, at *dingtalk.AtMeta, levels ...int) {
+	if len(levels) == 0 {
+		levels = []int{ERROR}
+	}
+	hook := NewDingTalkHook(dt, at)
+	for _, level := range levels {
+		l.AddHook(level, hook)
+	}
+}
+
+// levelName 获取日志级别名称
+func levelName(level int) string {
+	if level >= 0 && level < len(levelNames) {
+		return levelNames[level]
+	}
+	return fmt.Sprintf("LEVEL(%d)", level)
+}
@@ -3,6 +3,8 @@ package logger
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -24,6 +26,9 @@ const (
 	ERROR        // 错误级别：用于记录严重错误，会导致程序退出
 )
 
+// DefaultPrefix 未配置 Prefix 与 PHRYNUS 时使用的默认日志前缀
+const DefaultPrefix = "PHRYNUS"
+
 // LogConfig 日志配置结构体
 // 说明：
 //
@@ -36,9 +41,22 @@ type LogConfig struct {
 	LogDir       string       // 日志归档目录，用于存储轮转后的日志文件
 	MaxSize      int          // 单个日志文件的最大大小（KB），超过后会触发日志轮转
 	StdoutLevels map[int]bool // 控制哪些级别的日志需要同时输出到控制台
-	ColorOutput  bool         // 是否在控制台使用彩色输出
+	ColorOutput  bool         // 是否在控制台使用彩色输出，标准输出不是终端时自动关闭
 	ShowFileLine bool         // 是否在日志中显示代码文件名和行号
-	PHRYNUS      string       // 日志标识符，用于标识日志来源
+	Prefix       string       // 日志前缀，即日志行开头方括号内的标识，为空时使用 PHRYNUS，两者都为空时使用 DefaultPrefix
+	PHRYNUS      string       // Deprecated: 请使用 Prefix；Prefix 为空时作为日志前缀
+	CallerSkip   int          // 额外跳过的调用栈层数，封装logger时用于定位真实调用位置
+}
+
+// prefix 返回实际使用的日志前缀，Prefix 优先，其次是已弃用的 PHRYNUS，最后是 DefaultPrefix
+func (c LogConfig) prefix() string {
+	if c.Prefix != "" {
+		return c.Prefix
+	}
+	if c.PHRYNUS != "" {
+		return c.PHRYNUS
+	}
+	return DefaultPrefix
 }
 
 // logEntry 表示一个日志条目
@@ -52,6 +70,19 @@ type logEntry struct {
 	phrynus   string // 日志标识符
 }
 
+// LogEntry 日志钩子接收的日志条目
+type LogEntry struct {
+	Level    int       // 日志级别
+	Message  string    // 日志内容
+	FileLine string    // 代码文件名和行号（未开启 ShowFileLine 时为空）
+	Time     time.Time // 日志时间
+	Prefix   string    // 日志前缀
+	PHRYNUS  string    // Deprecated: 请使用 Prefix，与 Prefix 相同
+}
+
+// Hook 日志钩子函数
+type Hook func(entry LogEntry)
+
 // Logger 日志记录器结构体
 // 说明：
 //
@@ -65,11 +96,15 @@ type logEntry struct {
 //	7. 优化内存对齐减少填充字节
 type Logger struct {
 	// 8字节对齐的指针字段
-	file      *os.File       // 当前日志文件句柄
-	buffer    *bytes.Buffer  // 写入缓冲区
-	logChan   chan *logEntry // 日志条目通道
-	flushChan chan struct{}  // 刷新信号通道
-	closeChan chan struct{}  // 关闭信号通道
+	file       *os.File       // 当前日志文件句柄
+	writer     io.Writer      // 日志输出目标，文件模式下即为file
+	extra      []io.Writer    // 附加输出目标，接收相同的日志内容但不参与轮转（仅主logger持有）
+	capture    *bytes.Buffer  // 测试logger捕获的日志内容
+	buffer     *bytes.Buffer  // 写入缓冲区
+	logChan    chan *logEntry // 日志条目通道
+	flushChan  chan struct{}  // 刷新信号通道
+	closeChan  chan struct{}  // 关闭信号通道
+	writerDone chan struct{}  // 异步写入goroutine退出后关闭（仅主logger持有）
 
 	// 大结构体字段
 	config      LogConfig       // 日志配置信息
@@ -82,8 +117,13 @@ type Logger struct {
 	flushInterval time.Duration // 缓冲区刷新间隔
 
 	// 4字节对齐的字段
-	mux      sync.Mutex // 互斥锁，保证并发安全
-	isClosed int32      // 关闭状态标记（原子操作）
+	mux        sync.Mutex     // 互斥锁，保证并发安全
+	compressWG sync.WaitGroup // 跟踪进行中的归档压缩goroutine（仅主logger使用）
+	isClosed   int32          // 关闭状态标记（原子操作）
+	minLevel   int32          // 最低记录级别（原子操作），低于该级别的日志直接丢弃
+	noExit     bool           // ERROR级别日志不退出程序（测试logger使用）
+	useColor   bool           // 控制台是否实际输出颜色，ColorOutput开启且标准输出为终端时为true
+	syncMode   bool           // 同步写入，不经过异步通道（测试logger使用）
 
 	// 较小的字段
 	stdoutLevels map[int]bool // 控制台输出级别配置
@@ -92,6 +132,16 @@ type Logger struct {
 	// 父子关系管理（用于级联关闭）
 	parent   *Logger              // 父logger
 	children map[*Logger]struct{} // 子logger集合
+
+	hooks map[int][]Hook // 按级别注册的日志钩子（仅主logger持有）
+}
+
+// 日志级别的严重程度，级别常量的数值顺序与严重程度不一致，比较级别时使用该映射
+var levelSeverity = []int{
+	INFO:  1,
+	DEBUG: 0,
+	WARN:  2,
+	ERROR: 3,
 }
 
 // 日志级别名称映射
@@ -106,6 +156,7 @@ var levelNames = []string{
 // 说明：
 //
 //	根据提供的配置创建并初始化一个新的日志记录器
+//	打开 Filename 指定的日志文件后交给 NewLoggerWriter，超过 MaxSize 时按 LogDir 轮转并压缩
 //	同时启动后台的缓冲区刷新守护进程
 //
 // 参数：
@@ -133,10 +184,50 @@ func NewLogger(config LogConfig) (*Logger, error) {
 		return nil, err
 	}
 
-	info, err := file.Stat()
+	logger, err := NewLoggerWriter(file, config)
 	if err != nil {
+		file.Close()
 		return nil, err
 	}
+	return logger, nil
+}
+
+// NewLoggerWriter 创建写入任意 io.Writer 的日志记录器
+// 说明：
+//
+//	日志格式、控制台输出、钩子、克隆等行为与 NewLogger 一致，可用于标准输出、网络连接、内存缓冲区等目标
+//	只有 w 是以 config.Filename 打开的 *os.File 时才会按 MaxSize 轮转压缩，并在 Close 时关闭该文件
+//	其他写入目标不会轮转，也不会被 Close 关闭，由调用方自行管理
+//
+// 参数：
+//   - w: 日志写入目标，不能为nil
+//   - config: 日志配置信息，非文件目标时 Filename、LogDir、MaxSize 不生效
+//
+// 返回值：
+//   - *Logger: 日志记录器实例
+//   - error: 初始化过程中的错误
+//
+// 示例：
+//
+//	logger, err := NewLoggerWriter(os.Stdout, LogConfig{Prefix: "APP"})
+func NewLoggerWriter(w io.Writer, config LogConfig) (*Logger, error) {
+	if w == nil {
+		return nil, fmt.Errorf("writer must not be nil")
+	}
+	config.Prefix = config.prefix()
+	config.PHRYNUS = config.Prefix
+
+	// 仅当写入目标就是配置中的日志文件时才支持轮转
+	var file *os.File
+	var size int64
+	if f, ok := w.(*os.File); ok && config.Filename != "" && f.Name() == config.Filename {
+		info, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+		file = f
+		size = info.Size()
+	}
 
 	colorMap := [5]*color.Color{
 		INFO:  color.BgRGB(39, 174, 96).AddRGB(255, 255, 255),
@@ -149,15 +240,17 @@ func NewLogger(config LogConfig) (*Logger, error) {
 	logger := &Logger{
 		config:        config,
 		file:          file,
-		currentSize:   info.Size(),
+		writer:        w,
+		currentSize:   size,
 		colorMap:      colorMap,
 		stdoutLevels:  config.StdoutLevels,
 		buffer:        bytes.NewBuffer(nil),
 		flushInterval: time.Second,
-		phrynus:       config.PHRYNUS,
+		phrynus:       config.Prefix,
 		logChan:       make(chan *logEntry, 50000), // 缓冲50k个日志条目，减少阻塞
 		flushChan:     make(chan struct{}, 1),
 		closeChan:     make(chan struct{}),
+		writerDone:    make(chan struct{}),
 		bufferPool: sync.Pool{
 			New: func() interface{} {
 				return bytes.NewBuffer(make([]byte, 0, 256)) // 预分配256字节容量
@@ -169,7 +262,10 @@ func NewLogger(config LogConfig) (*Logger, error) {
 			},
 		},
 		isClosed: 0,
+		minLevel: DEBUG,
+		useColor: config.ColorOutput && isTerminal(os.Stdout),
 		children: make(map[*Logger]struct{}), // 初始化子logger集合
+		hooks:    make(map[int][]Hook),
 	}
 
 	go logger.asyncWriter()
@@ -188,6 +284,7 @@ func NewLogger(config LogConfig) (*Logger, error) {
 //	4. 触发缓冲区刷新
 //	5. 收到nil结束标记时优雅退出
 func (l *Logger) asyncWriter() {
+	defer close(l.writerDone)
 	for {
 		select {
 		case entry := <-l.logChan:
@@ -206,7 +303,6 @@ func (l *Logger) asyncWriter() {
 // processLogEntry 处理单个日志条目
 func (l *Logger) processLogEntry(entry *logEntry) {
 	l.mux.Lock()
-	defer l.mux.Unlock()
 
 	// 使用对象池获取缓冲区
 	buf := l.bufferPool.Get().(*bytes.Buffer)
@@ -233,12 +329,73 @@ func (l *Logger) processLogEntry(entry *logEntry) {
 		}
 	}
 
+	// 复制钩子列表后释放锁，钩子内再次调用logger不会死锁
+	hooks := append([]Hook(nil), l.hooks[entry.level]...)
+	l.mux.Unlock()
+
+	l.runHooks(hooks, entry)
+
 	// 错误级别直接退出
-	if entry.level == ERROR {
+	if entry.level == ERROR && !l.noExit {
 		os.Exit(1)
 	}
 }
 
+// runHooks 依次执行日志钩子，单个钩子panic不影响日志系统
+func (l *Logger) runHooks(hooks []Hook, entry *logEntry) {
+	if len(hooks) == 0 {
+		return
+	}
+	e := LogEntry{
+		Level:    entry.level,
+		Message:  entry.message,
+		FileLine: strings.TrimSpace(entry.fileLine),
+		Time:     entry.timestamp,
+		Prefix:   entry.phrynus,
+		PHRYNUS:  entry.phrynus,
+	}
+	for _, hook := range hooks {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					fmt.Printf("log hook panic: %v\n", r)
+				}
+			}()
+			hook(e)
+		}()
+	}
+}
+
+// AddHook 为指定级别注册日志钩子
+// 说明：
+//
+//	日志写入缓冲区后，在异步写入goroutine中按注册顺序同步调用钩子
+//	钩子执行时不持有logger的锁，钩子内部可以安全地再次记录日志
+//	（但不要在钩子中记录同级别日志，否则会形成循环）
+//	ERROR级别的钩子会在程序退出前执行完毕
+//	子logger注册的钩子统一挂在主logger上，对所有共享写入系统的logger生效
+//
+// 参数：
+//   - level: 日志级别
+//   - fn: 钩子函数
+func (l *Logger) AddHook(level int, fn func(entry LogEntry)) {
+	if fn == nil {
+		return
+	}
+	root := l.root()
+	root.mux.Lock()
+	defer root.mux.Unlock()
+	root.hooks[level] = append(root.hooks[level], fn)
+}
+
+// root 获取持有写入系统的主logger
+func (l *Logger) root() *Logger {
+	for l.parent != nil {
+		l = l.parent
+	}
+	return l
+}
+
 // formatLogEntry 格式化日志条目到缓冲区
 func (l *Logger) formatLogEntry(buf *bytes.Buffer, entry *logEntry) {
 	// 预估容量并分配缓冲区，避免多次扩容
@@ -260,24 +417,42 @@ func (l *Logger) formatLogEntry(buf *bytes.Buffer, entry *logEntry) {
 	buf.WriteString("\n")
 }
 
-// writeToConsole 输出到控制台（带彩色）
+// writeToConsole 输出到控制台
+// 说明：
+//
+//	未开启 ColorOutput 时只输出日志内容
+//	开启 ColorOutput 时输出带时间和级别的彩色日志，标准输出被重定向到文件或管道（如systemd、Docker采集日志）时输出相同格式但不带ANSI转义码的纯文本
 func (l *Logger) writeToConsole(entry *logEntry) {
 	if !l.config.ColorOutput {
 		fmt.Print(entry.message)
 		return
 	}
+	if entry.level < 0 || entry.level >= len(l.colorMap) || l.colorMap[entry.level] == nil {
+		return
+	}
+
+	codeLevel := fmt.Sprintf("[%s]", levelNames[entry.level])
+	title := fmt.Sprintf("[%s]", entry.timeStr)
+
+	if !l.useColor {
+		fmt.Printf("%s%s %s%s\n", title, codeLevel, entry.fileLine, entry.message)
+		return
+	}
 
-	if entry.level >= 0 && entry.level < len(l.colorMap) && l.colorMap[entry.level] != nil {
-		codeLevel := fmt.Sprintf("[%s]", levelNames[entry.level])
-		title := fmt.Sprintf("[%s]", entry.timeStr)
-		fileLineStr := entry.fileLine
+	fmt.Printf("%s%s %s%s\n",
+		l.colorMap[4].Sprint(title),
+		l.colorMap[entry.level].Sprint(codeLevel),
+		entry.fileLine,
+		entry.message)
+}
 
-		fmt.Printf("%s%s %s%s\n",
-			l.colorMap[4].Sprint(title),
-			l.colorMap[entry.level].Sprint(codeLevel),
-			fileLineStr,
-			entry.message)
+// isTerminal 判断文件是否为终端设备
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
 	}
+	return info.Mode()&os.ModeCharDevice != 0
 }
 
 // shouldFlush 判断是否应该刷新缓冲区
@@ -338,7 +513,8 @@ func (l *Logger) flushDaemon() {
 //	1. 检查缓冲区是否有内容
 //	2. 将内容写入文件
 //	3. 更新文件大小
-//	4. 必要时触发日志轮转
+//	4. 将相同内容写入附加输出目标
+//	5. 必要时触发日志轮转
 //
 // 返回值：
 //   - error: 写入过程中的错误
@@ -347,26 +523,97 @@ func (l *Logger) flushLocked() error {
 		return nil
 	}
 
-	content := l.buffer.String()
-	n, err := l.file.WriteString(content)
+	n, err := l.writer.Write(l.buffer.Bytes())
 	if err != nil {
 		return err
 	}
 	l.currentSize += int64(n)
+
+	// 附加输出目标的失败不影响主输出，返回第一个错误
+	var extraErr error
+	for _, w := range l.extra {
+		if _, err := w.Write(l.buffer.Bytes()); err != nil && extraErr == nil {
+			extraErr = fmt.Errorf("write extra writer failed: %v", err)
+		}
+	}
 	l.buffer.Reset()
 
-	if l.currentSize > int64(l.config.MaxSize)*1024 {
+	// 只有写入文件时才需要轮转
+	if l.file != nil && l.currentSize > int64(l.config.MaxSize)*1024 {
 		if err := l.rotateFileLocked(); err != nil {
 			return fmt.Errorf("rotate file failed: %v", err)
 		}
 	}
 
-	return nil
+	return extraErr
 }
 
+// AddWriter 添加附加输出目标
+// 说明：
+//
+//	附加目标接收与主输出完全相同的格式化日志行，例如同时写入本地文件和远程日志收集器
+//	轮转只作用于主日志文件，附加目标不会被轮转，也不会被 Close 关闭
+//	作用于共享写入系统，在克隆出的子logger上调用与在主logger上调用效果相同，可以并发调用
+//
+// 参数：
+//   - w: 附加输出目标，为nil时忽略
+//
+// 示例：
+//
+//	log.AddWriter(conn)
+func (l *Logger) AddWriter(w io.Writer) {
+	if w == nil {
+		return
+	}
+	root := l.root()
+	root.mux.Lock()
+	defer root.mux.Unlock()
+	root.extra = append(root.extra, w)
+}
+
+// RemoveWriter 移除通过 AddWriter 添加的附加输出目标
+// 说明：
+//
+//	按 == 比较查找目标，w 需为可比较的类型（通常是指针）；移除前缓冲区中的日志仍会写入该目标
+//
+// 参数：
+//   - w: 要移除的附加输出目标
+//
+// 返回值：
+//   - bool: 找到并移除时返回true
+func (l *Logger) RemoveWriter(w io.Writer) bool {
+	root := l.root()
+	root.mux.Lock()
+	defer root.mux.Unlock()
+
+	for i, existing := range root.extra {
+		if existing == w {
+			// 先把已缓冲的日志写出，保证移除前的日志完整送达
+			root.flushLocked()
+			root.extra = append(root.extra[:i:i], root.extra[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// callerDepth getFileInfo 到 Info/Infof 等方法调用方的调用栈深度
+const callerDepth = 3
+
 // getFileInfo 获取实时的文件信息
+// 说明：
+//
+//	在基础深度上额外跳过 CallerSkip 层，封装了logger的调用方可借此报告真实调用位置
+//	CallerSkip 超出调用栈时退回基础深度，而不是丢失行号
 func (l *Logger) getFileInfo() string {
-	_, file, line, ok := runtime.Caller(3) // 3表示调用栈的深度
+	skip := l.config.CallerSkip
+	if skip < 0 {
+		skip = 0
+	}
+	_, file, line, ok := runtime.Caller(callerDepth + skip)
+	if !ok && skip > 0 {
+		_, file, line, ok = runtime.Caller(callerDepth)
+	}
 	if ok {
 		return fmt.Sprintf("%s:%d ", filepath.Base(file), line)
 	}
@@ -385,11 +632,14 @@ func (l *Logger) getFileInfo() string {
 //   - level: 日志级别
 //   - format: 格式化字符串
 //   - args: 格式化参数
-func (l *Logger) log(level int, format string, args ...interface{}) {
+func (l *Logger) log(level int, format string, args []interface{}) {
 	// 检查是否已关闭
 	if atomic.LoadInt32(&l.isClosed) == 1 {
 		return
 	}
+	if level < 0 || level >= len(levelSeverity) || levelSeverity[level] < levelSeverity[atomic.LoadInt32(&l.minLevel)] {
+		return
+	}
 
 	// 使用对象池获取字符串构建器
 	builder := l.builderPool.Get().(*strings.Builder)
@@ -426,6 +676,12 @@ func (l *Logger) log(level int, format string, args ...interface{}) {
 		phrynus:   l.phrynus,
 	}
 
+	// 同步模式直接交给主logger处理，调用返回时日志已写入
+	if l.syncMode {
+		l.root().processLogEntry(entry)
+		return
+	}
+
 	// 安全地发送到通道，使用recover处理已关闭通道的情况
 	defer func() {
 		if r := recover(); r != nil {
@@ -483,6 +739,12 @@ func (l *Logger) rotateFileLocked() error {
 		return fmt.Errorf("failed to create log directory: %v", err)
 	}
 
+	// 同一秒内多次轮转时追加序号，避免覆盖尚未压缩或已压缩的归档
+	for seq := 1; fileExists(backupPath) || fileExists(backupPath+".gz"); seq++ {
+		backupPath = filepath.Join(l.config.LogDir, fmt.Sprintf("%s.%s-%d.log",
+			strings.TrimSuffix(baseName, filepath.Ext(baseName)), timeStamp, seq))
+	}
+
 	if err := os.Rename(l.config.Filename, backupPath); err != nil {
 		return fmt.Errorf("failed to rename log file: %v", err)
 	}
@@ -492,9 +754,12 @@ func (l *Logger) rotateFileLocked() error {
 		return fmt.Errorf("failed to create new log file: %v", err)
 	}
 	l.file = file
+	l.writer = file
 	l.currentSize = 0
 
+	l.compressWG.Add(1)
 	go func() {
+		defer l.compressWG.Done()
 		if err := compressLog(backupPath); err != nil {
 			fmt.Printf("压缩日志失败: %v\n", err)
 		}
@@ -503,6 +768,12 @@ func (l *Logger) rotateFileLocked() error {
 	return nil
 }
 
+// fileExists 判断文件是否存在
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
 // compressLog 压缩日志文件
 // 说明：
 //
@@ -599,8 +870,10 @@ func (l *Logger) Close() error {
 	close(l.logChan)
 	close(l.closeChan)
 
-	// 等待异步goroutine完成
-	time.Sleep(200 * time.Millisecond)
+	// 等待异步goroutine完成（同步模式没有异步goroutine）
+	if !l.syncMode {
+		time.Sleep(200 * time.Millisecond)
+	}
 
 	l.mux.Lock()
 	defer l.mux.Unlock()
@@ -618,6 +891,86 @@ func (l *Logger) Close() error {
 	return nil
 }
 
+// Shutdown 优雅关闭日志记录器，保证退出前日志和归档不丢失
+// 说明：
+//
+//	适合在收到 SIGTERM 等信号、进程退出前调用：
+//	1. 级联关闭所有子logger，之后记录的日志会被丢弃
+//	2. 等待异步写入goroutine处理完通道中已提交的日志
+//	3. 停止刷新守护进程，最后一次刷新缓冲区并关闭日志文件
+//	4. 等待进行中的归档压缩完成
+//	与 Close 不同，Shutdown 不依赖固定的等待时间
+//	ctx 在等待写入goroutine时到期，仍会停止刷新守护进程、写出缓冲区并关闭文件，通道中尚未处理的日志会丢失
+//	在子logger上调用等同于 Close；已经 Close 过的主logger仍会等待剩余的压缩任务
+//
+// 参数：
+//   - ctx: 控制最长等待时间
+//
+// 返回值：
+//   - error: 刷新或关闭文件的错误，等待超时时返回 ctx 的错误（与刷新或关闭文件的错误合并）
+//
+// 示例：
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	log.Shutdown(ctx)
+func (l *Logger) Shutdown(ctx context.Context) error {
+	if l.parent != nil {
+		return l.Close()
+	}
+
+	var closeErr error
+	if atomic.CompareAndSwapInt32(&l.isClosed, 0, 1) {
+		l.mux.Lock()
+		children := make([]*Logger, 0, len(l.children))
+		for child := range l.children {
+			children = append(children, child)
+		}
+		l.mux.Unlock()
+		for _, child := range children {
+			child.Close()
+		}
+
+		// 只关闭日志通道，异步写入goroutine会先处理完已提交的日志再退出
+		close(l.logChan)
+		var waitErr error
+		if l.writerDone != nil {
+			select {
+			case <-l.writerDone:
+			case <-ctx.Done():
+				// 超时也要停止守护进程并关闭文件，尽力写出已进入缓冲区的日志
+				waitErr = ctx.Err()
+			}
+		}
+		close(l.closeChan)
+
+		l.mux.Lock()
+		closeErr = l.flushLocked()
+		if l.file != nil {
+			if err := l.file.Close(); err != nil && closeErr == nil {
+				closeErr = err
+			}
+		}
+		l.mux.Unlock()
+
+		if waitErr != nil {
+			return errors.Join(waitErr, closeErr)
+		}
+	}
+
+	compressed := make(chan struct{})
+	go func() {
+		l.compressWG.Wait()
+		close(compressed)
+	}()
+	select {
+	case <-compressed:
+		return closeErr
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // 以下是各个日志级别的记录方法
 // 说明：
 //   提供了两组方法：
@@ -625,40 +978,46 @@ func (l *Logger) Close() error {
 //   2. f后缀方法：支持格式化字符串
 
 // Info 记录信息级别日志
-func (l *Logger) Info(args ...interface{}) { l.log(INFO, "", args...) }
+func (l *Logger) Info(args ...interface{}) { l.log(INFO, "", args) }
 
 // Debug 记录调试级别日志
-func (l *Logger) Debug(args ...interface{}) { l.log(DEBUG, "", args...) }
+func (l *Logger) Debug(args ...interface{}) { l.log(DEBUG, "", args) }
 
 // Warn 记录警告级别日志
-func (l *Logger) Warn(args ...interface{}) { l.log(WARN, "", args...) }
+func (l *Logger) Warn(args ...interface{}) { l.log(WARN, "", args) }
 
 // Error 记录错误级别日志
 // 注意：调用此方法会导致程序退出
-func (l *Logger) Error(args ...interface{}) { l.log(ERROR, "", args...) }
+func (l *Logger) Error(args ...interface{}) { l.log(ERROR, "", args) }
 
 // Infof 记录带格式的信息级别日志
-func (l *Logger) Infof(format string, args ...interface{}) { l.log(INFO, format, args...) }
+func (l *Logger) Infof(format string, args ...interface{}) { l.log(INFO, format, args) }
 
 // Debugf 记录带格式的调试级别日志
-func (l *Logger) Debugf(format string, args ...interface{}) { l.log(DEBUG, format, args...) }
+func (l *Logger) Debugf(format string, args ...interface{}) { l.log(DEBUG, format, args) }
 
 // Warnf 记录带格式的警告级别日志
-func (l *Logger) Warnf(format string, args ...interface{}) { l.log(WARN, format, args...) }
+func (l *Logger) Warnf(format string, args ...interface{}) { l.log(WARN, format, args) }
 
 // Errorf 记录带格式的错误级别日志
 // 注意：调用此方法会导致程序退出
-func (l *Logger) Errorf(format string, args ...interface{}) { l.log(ERROR, format, args...) }
+func (l *Logger) Errorf(format string, args ...interface{}) { l.log(ERROR, format, args) }
 
-// Clone 复制Logger实例并更换PHRYNUS标识符
+// Clone 复制Logger实例并更换日志前缀
 // 说明：
 //
-//	创建一个新的Logger实例，复制原有配置但使用新的PHRYNUS标识符
-//	主要用于在同一个应用中创建多个具有不同标识符的日志记录器
-//	注意：克隆的Logger实例会共享同一个异步写入系统（通道和goroutine）以提高效率
+//	创建一个新的Logger实例，复制原有配置但使用新的日志前缀
+//	主要用于在同一个应用中创建多个具有不同标识符的日志记录器，如 "GORM"、"GIN"
+//	共享的部分：
+//	- 日志通道和写入goroutine，所有克隆的日志由主logger统一写入
+//	- 日志文件、缓冲区和轮转，主logger的锁保证每条日志整行写入，不会交错
+//	- 控制台输出级别和日志钩子
+//	独立的部分：
+//	- 日志前缀和是否显示文件行号
+//	- 最低记录级别，克隆时继承当前值，之后可通过 SetMinLevel 单独调整
 //
 // 参数：
-//   - newPHRYNUS: 新的日志标识符
+//   - newPHRYNUS: 新的日志前缀，为空时使用 DefaultPrefix
 //   - ShowFileLine: 是否显示文件行号
 //
 // 返回值：
@@ -667,8 +1026,12 @@ func (l *Logger) Clone(newPHRYNUS string, ShowFileLine bool) *Logger {
 	l.mux.Lock()
 	defer l.mux.Unlock()
 
-	// 复制配置并更新PHRYNUS
+	// 复制配置并更新日志前缀
+	if newPHRYNUS == "" {
+		newPHRYNUS = DefaultPrefix
+	}
 	newConfig := l.config
+	newConfig.Prefix = newPHRYNUS
 	newConfig.PHRYNUS = newPHRYNUS
 	newConfig.ShowFileLine = ShowFileLine
 
@@ -676,6 +1039,11 @@ func (l *Logger) Clone(newPHRYNUS string, ShowFileLine bool) *Logger {
 	newLogger := &Logger{
 		config:        newConfig,
 		file:          l.file, // 共享同一个文件句柄
+		writer:        l.writer,
+		capture:       l.capture,
+		noExit:        l.noExit,
+		syncMode:      l.syncMode,
+		useColor:      l.useColor,
 		currentSize:   l.currentSize,
 		colorMap:      l.colorMap,     // 共享颜色映射
 		stdoutLevels:  l.stdoutLevels, // 共享输出级别配置
@@ -695,9 +1063,10 @@ func (l *Logger) Clone(newPHRYNUS string, ShowFileLine bool) *Logger {
 				return &strings.Builder{}
 			},
 		},
-		isClosed: l.isClosed,                 // 共享关闭状态
-		parent:   l,                          // 设置父logger
-		children: make(map[*Logger]struct{}), // 初始化子logger集合
+		isClosed: l.isClosed,                    // 共享关闭状态
+		minLevel: atomic.LoadInt32(&l.minLevel), // 继承当前最低记录级别
+		parent:   l,                             // 设置父logger
+		children: make(map[*Logger]struct{}),    // 初始化子logger集合
 	}
 
 	// 将新logger添加到父logger的子logger集合中
@@ -705,3 +1074,26 @@ func (l *Logger) Clone(newPHRYNUS string, ShowFileLine bool) *Logger {
 
 	return newLogger
 }
+
+// SetMinLevel 设置最低记录级别
+// 说明：
+//
+//	严重程度从低到高为 DEBUG < INFO < WARN < ERROR，低于该级别的日志直接丢弃，不写文件、不输出控制台、不触发钩子
+//	只影响当前logger，已克隆的子logger不受影响，可以并发调用
+//	默认级别为 DEBUG，即记录全部日志
+//
+// 参数：
+//   - level: 最低记录级别，无效级别会被忽略
+//
+// 返回值：
+//   - *Logger: 当前logger，便于链式调用
+//
+// 示例：
+//
+//	gormLog := log.Clone("GORM", false).SetMinLevel(logger.WARN)
+func (l *Logger) SetMinLevel(level int) *Logger {
+	if level >= 0 && level < len(levelSeverity) {
+		atomic.StoreInt32(&l.minLevel, int32(level))
+	}
+	return l
+}
@@ -0,0 +1,308 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestShutdownExpiredContext(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "app.log")
+	log, err := NewLogger(LogConfig{Filename: filename, LogDir: t.TempDir(), MaxSize: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 直接放入缓冲区，模拟已由写入goroutine处理但尚未刷新的日志
+	log.mux.Lock()
+	log.buffer.WriteString("pending line\n")
+	log.mux.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := log.Shutdown(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		t.Fatalf("Shutdown error = %v, want nil or context.Canceled", err)
+	}
+
+	select {
+	case <-log.closeChan:
+	default:
+		t.Fatal("closeChan not closed, flush daemon would leak")
+	}
+	if _, err := log.file.Write([]byte("x")); !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("file write error = %v, want os.ErrClosed", err)
+	}
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "pending line") {
+		t.Fatalf("buffered line not flushed, file content %q", content)
+	}
+
+	// 再次关闭不应报错或阻塞
+	if err := log.Shutdown(context.Background()); err != nil {
+		t.Fatalf("second Shutdown error = %v", err)
+	}
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close after Shutdown error = %v", err)
+	}
+}
+
+func TestCloneConcurrentPrefixAndLevel(t *testing.T) {
+	root := NewTestLogger()
+	gorm := root.Clone("GORM", false).SetMinLevel(WARN)
+	gin := root.Clone("GIN", false)
+
+	const n = 200
+	var wg sync.WaitGroup
+	for _, l := range []*Logger{gorm, gin} {
+		wg.Add(1)
+		go func(l *Logger) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				l.Debugf("debug %d", i)
+				l.Infof("info %d", i)
+				l.Warnf("warn %d", i)
+			}
+		}(l)
+	}
+	wg.Wait()
+
+	counts := map[string]int{}
+	for _, line := range root.Lines() {
+		prefix, rest, ok := strings.Cut(strings.TrimPrefix(line, "["), "]")
+		if !ok {
+			t.Fatalf("malformed line %q", line)
+		}
+		_, rest, _ = strings.Cut(rest, "][")
+		level, msg, _ := strings.Cut(rest, "] ")
+		// 每行的前缀与级别必须和消息内容一致，说明并发写入没有交错
+		if !strings.HasPrefix(msg, strings.ToLower(level)+" ") {
+			t.Fatalf("level %s does not match message in %q", level, line)
+		}
+		counts[prefix+" "+level]++
+	}
+
+	want := map[string]int{
+		"GORM WARN": n,
+		"GIN DEBUG": n,
+		"GIN INFO":  n,
+		"GIN WARN":  n,
+	}
+	for key, c := range want {
+		if counts[key] != c {
+			t.Errorf("%s lines = %d, want %d", key, counts[key], c)
+		}
+	}
+	if len(counts) != len(want) {
+		t.Errorf("unexpected prefix/level combinations: %v", counts)
+	}
+	if got := root.Clone("", false).phrynus; got != DefaultPrefix {
+		t.Errorf("empty clone prefix = %q, want %q", got, DefaultPrefix)
+	}
+}
+
+// logVia 模拟一层封装logger的辅助函数，返回其中 l.Info 调用所在的行号
+func logVia(l *Logger, msg string) int {
+	_, _, line, _ := runtime.Caller(0)
+	l.Info(msg)
+	return line + 1
+}
+
+func TestCallerSkip(t *testing.T) {
+	direct := NewTestLogger()
+	direct.config.ShowFileLine = true
+
+	wrapped := NewTestLogger()
+	wrapped.config.ShowFileLine = true
+	wrapped.config.CallerSkip = 1
+
+	wrapperLine := logVia(direct, "direct")
+	_, _, callerLine, _ := runtime.Caller(0)
+	logVia(wrapped, "wrapped")
+	callerLine++ // 上一行的 logVia 调用
+
+	if want := fmt.Sprintf("logger_test.go:%d direct", wrapperLine); !strings.HasSuffix(direct.Lines()[0], want) {
+		t.Errorf("CallerSkip 0 line = %q, want suffix %q", direct.Lines()[0], want)
+	}
+	if want := fmt.Sprintf("logger_test.go:%d wrapped", callerLine); !strings.HasSuffix(wrapped.Lines()[0], want) {
+		t.Errorf("CallerSkip 1 line = %q, want suffix %q", wrapped.Lines()[0], want)
+	}
+
+	// 超出调用栈时退回基础深度，仍然报告行号
+	deep := NewTestLogger()
+	deep.config.ShowFileLine = true
+	deep.config.CallerSkip = 1000
+	deep.Info("deep")
+	if !strings.Contains(deep.Lines()[0], "logger_test.go:") {
+		t.Errorf("CallerSkip beyond stack line = %q", deep.Lines()[0])
+	}
+}
+
+func TestLogConfigPrefix(t *testing.T) {
+	cases := []struct {
+		name   string
+		config LogConfig
+		want   string
+	}{
+		{"default", LogConfig{}, DefaultPrefix},
+		{"prefix", LogConfig{Prefix: "APP"}, "APP"},
+		{"deprecated PHRYNUS", LogConfig{PHRYNUS: "OLD"}, "OLD"},
+		{"prefix wins", LogConfig{Prefix: "APP", PHRYNUS: "OLD"}, "APP"},
+	}
+	for _, c := range cases {
+		var buf bytes.Buffer
+		log, err := NewLoggerWriter(&buf, c.config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var hookPrefix string
+		log.AddHook(INFO, func(entry LogEntry) { hookPrefix = entry.Prefix })
+		log.Info("hello")
+		if err := log.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(buf.String(), "["+c.want+"][") {
+			t.Errorf("%s: line = %q, want prefix [%s]", c.name, buf.String(), c.want)
+		}
+		if hookPrefix != c.want {
+			t.Errorf("%s: hook prefix = %q, want %q", c.name, hookPrefix, c.want)
+		}
+	}
+}
+
+func TestAddHookDelivery(t *testing.T) {
+	log := NewTestLogger()
+	var got []LogEntry
+	log.AddHook(WARN, func(entry LogEntry) { got = append(got, entry) })
+
+	log.Info("not hooked")
+	log.Clone("CHILD", false).Warnf("disk %d%%", 90)
+
+	if len(got) != 1 {
+		t.Fatalf("hook called %d times, want 1", len(got))
+	}
+	if got[0].Level != WARN || got[0].Message != "disk 90%" || got[0].Prefix != "CHILD" {
+		t.Fatalf("hook entry = %+v", got[0])
+	}
+}
+
+func TestHookLogsBack(t *testing.T) {
+	log := NewTestLogger()
+	log.AddHook(WARN, func(entry LogEntry) { log.Info("hook saw: " + entry.Message) })
+
+	done := make(chan struct{})
+	go func() {
+		log.Warn("first")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("logging from a hook deadlocked")
+	}
+
+	lines := log.Lines()
+	if len(lines) != 2 || !strings.HasSuffix(lines[1], "hook saw: first") {
+		t.Fatalf("lines = %q", lines)
+	}
+}
+
+func TestHookPanic(t *testing.T) {
+	log := NewTestLogger()
+	var after int
+	log.AddHook(WARN, func(LogEntry) { panic("boom") })
+	log.AddHook(WARN, func(LogEntry) { after++ })
+
+	log.Warn("one")
+	log.Warn("two")
+
+	if after != 2 {
+		t.Fatalf("hook after panicking one ran %d times, want 2", after)
+	}
+	if lines := log.Lines(); len(lines) != 2 {
+		t.Fatalf("lines = %q", lines)
+	}
+}
+
+func TestAsyncHookDropsWhenFull(t *testing.T) {
+	started := make(chan struct{}, 8)
+	release := make(chan struct{})
+	sent := make(chan string, 8)
+	hook := newAsyncHook(1, time.Second, func(entry LogEntry) {
+		started <- struct{}{}
+		<-release
+		sent <- entry.Message
+	})
+
+	// 第一条被goroutine取走后阻塞在发送中，第二条占满队列，第三条被丢弃
+	begin := time.Now()
+	hook(LogEntry{Level: WARN, Message: "a"})
+	<-started
+	hook(LogEntry{Level: WARN, Message: "b"})
+	hook(LogEntry{Level: WARN, Message: "c"})
+	if time.Since(begin) > 500*time.Millisecond {
+		t.Fatal("hook blocked while sender was busy")
+	}
+
+	close(release)
+	<-started // b 已出队
+	// ERROR级别等待本条发送完成后返回
+	hook(LogEntry{Level: ERROR, Message: "d"})
+	close(sent)
+
+	var got []string
+	for msg := range sent {
+		got = append(got, msg)
+	}
+	if strings.Join(got, "") != "abd" {
+		t.Fatalf("sent = %q, want a, b, d", got)
+	}
+}
+
+// captureStdout 执行 fn 并返回其间写入标准输出的内容
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = stdout
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	r.Close()
+	return buf.String()
+}
+
+func TestWriteToConsole(t *testing.T) {
+	entry := &logEntry{level: INFO, message: "hello", timeStr: "12:00:00.000"}
+
+	plain := NewTestLogger()
+	if got := captureStdout(t, func() { plain.writeToConsole(entry) }); got != "hello" {
+		t.Fatalf("ColorOutput=false output = %q, want %q", got, "hello")
+	}
+
+	// 开启颜色但标准输出不是终端：保留时间和级别，去掉转义码
+	colored, err := NewLoggerWriter(&bytes.Buffer{}, LogConfig{ColorOutput: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer colored.Close()
+	colored.useColor = false
+	if got := captureStdout(t, func() { colored.writeToConsole(entry) }); got != "[12:00:00.000][INFO] hello\n" {
+		t.Fatalf("non-TTY ColorOutput=true output = %q", got)
+	}
+}
@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// NewTestLogger 创建用于单元测试的日志记录器
+// 说明：
+//
+//	日志写入内存缓冲区而不是文件，不会创建目录、不会轮转：
+//	1. 同步写入，Info 等方法返回时日志已经可以通过 Lines 读取
+//	2. ERROR级别日志不会调用 os.Exit
+//	3. 默认不输出到控制台，不显示文件行号
+//	日志格式、克隆、钩子、最低记录级别等行为与普通logger一致
+//
+// 返回值：
+//   - *Logger: 测试用日志记录器
+//
+// 示例：
+//
+//	log := logger.NewTestLogger()
+//	doSomething(log)
+//	for _, line := range log.Lines() {
+//		fmt.Println(line)
+//	}
+func NewTestLogger() *Logger {
+	capture := bytes.NewBuffer(nil)
+	return &Logger{
+		config:        LogConfig{Prefix: "TEST", PHRYNUS: "TEST"},
+		writer:        capture,
+		capture:       capture,
+		colorMap:      [5]*color.Color{},
+		stdoutLevels:  map[int]bool{},
+		buffer:        bytes.NewBuffer(nil),
+		flushInterval: time.Second,
+		phrynus:       "TEST",
+		logChan:       make(chan *logEntry),
+		flushChan:     make(chan struct{}, 1),
+		closeChan:     make(chan struct{}),
+		bufferPool: sync.Pool{
+			New: func() interface{} {
+				return bytes.NewBuffer(make([]byte, 0, 256))
+			},
+		},
+		builderPool: sync.Pool{
+			New: func() interface{} {
+				return &strings.Builder{}
+			},
+		},
+		minLevel: DEBUG,
+		noExit:   true,
+		syncMode: true,
+		children: make(map[*Logger]struct{}),
+		hooks:    make(map[int][]Hook),
+	}
+}
+
+// Lines 获取测试logger捕获的日志行
+// 说明：
+//
+//	返回 NewTestLogger 创建的logger（及其克隆）已记录的所有日志行，不含末尾换行
+//	普通logger没有捕获缓冲区，返回nil
+//
+// 返回值：
+//   - []string: 按记录顺序排列的日志行
+func (l *Logger) Lines() []string {
+	root := l.root()
+	if root.capture == nil {
+		return nil
+	}
+
+	root.mux.Lock()
+	defer root.mux.Unlock()
+	root.flushLocked()
+
+	content := strings.TrimSuffix(root.capture.String(), "\n")
+	if content == "" {
+		return nil
+	}
+	return strings.Split(content, "\n")
+}
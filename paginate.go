@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+)
+
+// PageFunc 拉取一页数据，cursor 为本页游标（时间、页码或接口返回的游标）
+// 返回本页数据、下一页游标，以及是否已经是最后一页
+type PageFunc[T any, C comparable] func(ctx context.Context, cursor C) (items []T, next C, done bool, err error)
+
+// PaginateEach 从 start 开始逐页调用 fetch，并对每条数据调用 fn
+// maxItems 大于0时处理满该数量后停止；fn 返回错误时停止并返回该错误
+// fetch 返回 done 或空页时结束；未结束但游标没有变化时返回错误，避免死循环
+// ctx 取消时返回 ctx 的错误
+func PaginateEach[T any, C comparable](ctx context.Context, start C, fetch PageFunc[T, C], maxItems int, fn func(T) error) error {
+	if fetch == nil || fn == nil {
+		return fmt.Errorf("fetch and fn must not be nil")
+	}
+
+	count := 0
+	cursor := start
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		items, next, done, err := fetch(ctx, cursor)
+		if err != nil {
+			return fmt.Errorf("fetch page %v: %w", cursor, err)
+		}
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+			count++
+			if maxItems > 0 && count >= maxItems {
+				return nil
+			}
+		}
+
+		if done || len(items) == 0 {
+			return nil
+		}
+		if next == cursor {
+			return fmt.Errorf("pagination cursor did not advance: %v", cursor)
+		}
+		cursor = next
+	}
+}
+
+// Paginate 拉取全部分页数据，规则同 PaginateEach
+// 出错时返回已获取的部分数据和错误
+func Paginate[T any, C comparable](ctx context.Context, start C, fetch PageFunc[T, C], maxItems int) ([]T, error) {
+	var result []T
+	err := PaginateEach(ctx, start, fetch, maxItems, func(item T) error {
+		result = append(result, item)
+		return nil
+	})
+	return result, err
+}
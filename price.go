@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RoundMode 价格按最小变动单位取整的方向
+type RoundMode int
+
+const (
+	RoundNearest RoundMode = iota // 四舍五入到最近的价位
+	RoundFloor                    // 向下取整
+	RoundCeil                     // 向上取整
+)
+
+// roundEpsilon 取整前的容差，避免 0.3/0.1 之类的浮点误差导致多取或少取一个价位
+const roundEpsilon = 1e-9
+
+// PctChange 返回从 from 到 to 的涨跌幅（百分比），from 为0时返回0
+func PctChange(from, to float64) float64 {
+	if from == 0 {
+		return 0
+	}
+	return (to - from) / from * 100
+}
+
+// AddPct 返回在 price 基础上增加 pct% 后的价格，pct 为负数时表示减少
+func AddPct(price, pct float64) float64 {
+	return price * (1 + pct/100)
+}
+
+// TicksBetween 返回从 a 到 b 相差的价位数，b 高于 a 时为正，tickSize 小于等于0时返回0
+func TicksBetween(a, b, tickSize float64) int {
+	if tickSize <= 0 {
+		return 0
+	}
+	return int(math.Round((b - a) / tickSize))
+}
+
+// RoundToTick 按最小变动单位 tickSize 取整，结果按 tickSize 的小数位数消除浮点误差
+// tickSize 小于等于0时原样返回
+func RoundToTick(price, tickSize float64, mode RoundMode) float64 {
+	if tickSize <= 0 {
+		return price
+	}
+
+	ticks := price / tickSize
+	switch mode {
+	case RoundFloor:
+		ticks = math.Floor(ticks + roundEpsilon)
+	case RoundCeil:
+		ticks = math.Ceil(ticks - roundEpsilon)
+	default:
+		ticks = math.Round(ticks)
+	}
+
+	scale := math.Pow10(tickDecimals(tickSize))
+	return math.Round(ticks*tickSize*scale) / scale
+}
+
+// tickDecimals 返回 tickSize 的小数位数，如 0.001 返回3
+func tickDecimals(tickSize float64) int {
+	s := strconv.FormatFloat(tickSize, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// maxRetryBackoff 单次重试等待的上限
+const maxRetryBackoff = time.Minute
+
+// PermanentError 不应重试的错误，由 Permanent 包装
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// Permanent 将错误标记为不可重试，Retry 遇到后立即返回原错误
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// Retry 执行 fn，失败时按指数退避重试，最多执行 attempts 次
+// 第 n 次重试前等待 backoff*2^(n-1)，并乘以 [0.5, 1.5) 的随机抖动，单次等待不超过 maxRetryBackoff
+// fn 返回 Permanent 包装的错误时不再重试；ctx 取消时停止重试，尚未执行过 fn 时返回 ctx 的错误
+// 返回最后一次的错误，Permanent 包装会被去掉
+func Retry(ctx context.Context, attempts int, backoff time.Duration, fn func() error) error {
+	if attempts <= 0 {
+		attempts = 1
+	}
+
+	var err error
+	delay := backoff
+	for i := 0; i < attempts; i++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if err != nil {
+				return err
+			}
+			return ctxErr
+		}
+
+		err = fn()
+		if err == nil {
+			return nil
+		}
+		var permanent *PermanentError
+		if errors.As(err, &permanent) {
+			return permanent.Err
+		}
+		if i == attempts-1 {
+			break
+		}
+
+		wait := time.Duration(float64(delay) * (0.5 + rand.Float64()))
+		if wait > maxRetryBackoff {
+			wait = maxRetryBackoff
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		if delay < maxRetryBackoff {
+			delay *= 2
+		}
+	}
+	return err
+}
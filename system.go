@@ -1,17 +1,26 @@
 package utils
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 var ipEndpoints = []string{
@@ -26,6 +35,17 @@ var ipEndpoints = []string{
 var Ip string = "0.0.0.0"
 var ipMutex sync.RWMutex
 
+// probeGroup 合并并发的IP检测和硬件ID查询，同一时间每种探测只执行一次
+var probeGroup singleflight.Group
+
+// sharedProbe 通过 probeGroup 执行探测，并发调用共享同一次结果
+func sharedProbe(key string, probe func() string) string {
+	v, _, _ := probeGroup.Do(key, func() (interface{}, error) {
+		return probe(), nil
+	})
+	return v.(string)
+}
+
 // ========== 系统信息 ==========
 
 // SystemInfo 系统信息
@@ -110,6 +130,7 @@ func isValidIP(ip string) bool {
 
 // GetOutboundIP 获取对外通信的IP地址
 // 如果已缓存有效IP，直接返回；否则尝试多个服务获取IP并缓存结果
+// 并发调用时共享同一次检测，不会重复请求
 func GetOutboundIP() string {
 	// 先检查是否已有缓存的有效IP
 	ipMutex.RLock()
@@ -119,6 +140,11 @@ func GetOutboundIP() string {
 	}
 	ipMutex.RUnlock()
 
+	return sharedProbe("outbound_ip", detectOutboundIP)
+}
+
+// detectOutboundIP 依次尝试IP检测服务并缓存结果
+func detectOutboundIP() string {
 	// 创建带超时的HTTP客户端
 	client := &http.Client{
 		Timeout: 3 * time.Second,
@@ -126,32 +152,86 @@ func GetOutboundIP() string {
 
 	// 尝试每个IP检测服务
 	for _, endpoint := range ipEndpoints {
-		resp, err := client.Get(endpoint)
+		ipStr, err := fetchIP(context.Background(), client, endpoint)
 		if err != nil {
 			continue // 尝试下一个服务
 		}
 
-		// 读取响应体内容
-		ip, err := io.ReadAll(resp.Body)
-		resp.Body.Close() // 立即关闭响应体
+		// 缓存有效的IP地址
+		ipMutex.Lock()
+		Ip = ipStr
+		ipMutex.Unlock()
+		return ipStr
+	}
 
-		if err != nil {
-			continue // 尝试下一个服务
+	// 所有服务都失败，返回默认值
+	return "0.0.0.0"
+}
+
+// GetOutboundIPVerbose 获取对外通信的IP地址，并返回应答的服务和耗时
+// 按 ipEndpoints 顺序依次尝试，source 为应答的服务地址，latency 为该服务的请求耗时
+// 命中缓存时 source 为 "cache"、latency 为0；bypassCache 为true时跳过缓存重新检测，用于诊断
+// 检测成功后同样会更新缓存
+func GetOutboundIPVerbose(ctx context.Context, bypassCache bool) (ip string, source string, latency time.Duration, err error) {
+	if !bypassCache {
+		ipMutex.RLock()
+		cached := Ip
+		ipMutex.RUnlock()
+		if cached != "0.0.0.0" && isValidIP(cached) {
+			return cached, "cache", 0, nil
+		}
+	}
+
+	client := &http.Client{
+		Timeout: 3 * time.Second,
+	}
+
+	var errs []string
+	for _, endpoint := range ipEndpoints {
+		if err := ctx.Err(); err != nil {
+			return "0.0.0.0", "", 0, err
 		}
 
-		// 验证获取到的IP地址
-		ipStr := strings.TrimSpace(string(ip))
-		if isValidIP(ipStr) {
-			// 缓存有效的IP地址
-			ipMutex.Lock()
-			Ip = ipStr
-			ipMutex.Unlock()
-			return ipStr
+		start := time.Now()
+		ipStr, err := fetchIP(ctx, client, endpoint)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", endpoint, err))
+			continue
 		}
+		latency = time.Since(start)
+
+		ipMutex.Lock()
+		Ip = ipStr
+		ipMutex.Unlock()
+		return ipStr, endpoint, latency, nil
 	}
 
-	// 所有服务都失败，返回默认值
-	return "0.0.0.0"
+	return "0.0.0.0", "", 0, fmt.Errorf("all ip endpoints failed: %s", strings.Join(errs, "; "))
+}
+
+// fetchIP 请求单个IP检测服务并校验返回的IP地址
+func fetchIP(ctx context.Context, client *http.Client, endpoint string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	ip, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	ipStr := strings.TrimSpace(string(ip))
+	if !isValidIP(ipStr) {
+		return "", fmt.Errorf("invalid ip response: %q", ipStr)
+	}
+	return ipStr, nil
 }
 
 // ResetIPCache 重置IP缓存，强制下次调用GetOutboundIP时重新获取
@@ -171,7 +251,13 @@ func GetComputerName() string {
 }
 
 // 获取CPU ID
+// 并发调用时共享同一次查询
 func GetCpuId() string {
+	return sharedProbe("cpu_id", getCpuId)
+}
+
+// getCpuId 获取CPU ID
+func getCpuId() string {
 	switch runtime.GOOS {
 	case "windows":
 		cmd := exec.Command("wmic", "cpu", "get", "ProcessorID")
@@ -271,7 +357,13 @@ func GetCpuId() string {
 }
 
 // 获取主板 ID
+// 并发调用时共享同一次查询
 func GetBaseboardId() string {
+	return sharedProbe("baseboard_id", getBaseboardId)
+}
+
+// getBaseboardId 获取主板 ID
+func getBaseboardId() string {
 	switch runtime.GOOS {
 	case "windows":
 		cmd := exec.Command("wmic", "baseboard", "get", "serialnumber")
@@ -354,7 +446,13 @@ func GetBaseboardId() string {
 }
 
 // 获取内存 ID
+// 并发调用时共享同一次查询
 func GetMemoryId() string {
+	return sharedProbe("memory_id", getMemoryId)
+}
+
+// getMemoryId 获取内存 ID
+func getMemoryId() string {
 	switch runtime.GOOS {
 	case "windows":
 		cmd := exec.Command("wmic", "memorychip", "get", "serialnumber")
@@ -443,7 +541,13 @@ func GetMemoryId() string {
 }
 
 // 取机器码UUID
+// 并发调用时共享同一次查询
 func GetMachineCode() string {
+	return sharedProbe("machine_code", getMachineCode)
+}
+
+// getMachineCode 取机器码UUID
+func getMachineCode() string {
 	switch runtime.GOOS {
 	case "windows":
 		cmd := exec.Command("wmic", "csproduct", "get", "uuid")
@@ -555,19 +659,163 @@ func GetProxy() (string, string) {
 	return httpProxy, httpsProxy
 }
 
+// ProxyForURL 获取访问指定地址时应使用的代理
+// 与 http.ProxyFromEnvironment 规则一致：按协议读取 HTTP_PROXY/HTTPS_PROXY，并排除 NO_PROXY 中的主机、域名和CIDR
+// localhost 与回环地址始终不走代理；不需要代理时返回空字符串
+// 注意：net/http 只在首次调用时读取环境变量，之后修改环境变量不会生效
+func ProxyForURL(rawurl string) (string, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid url: %s", rawurl)
+	}
+
+	proxy, err := http.ProxyFromEnvironment(&http.Request{URL: u})
+	if err != nil || proxy == nil {
+		return "", err
+	}
+	return proxy.String(), nil
+}
+
+// ========== 资源使用 ==========
+
+// ResourceUsage 当前资源使用情况，获取失败的字段为0
+type ResourceUsage struct {
+	GoHeapAlloc  uint64 `json:"go_heap_alloc"` // Go堆上已分配的字节数
+	GoSys        uint64 `json:"go_sys"`        // Go运行时向系统申请的字节数
+	NumGoroutine int    `json:"num_goroutine"` // 当前goroutine数量
+	ProcessRSS   uint64 `json:"process_rss"`   // 进程常驻内存（字节）
+	MemTotal     uint64 `json:"mem_total"`     // 系统内存总量（字节）
+	MemFree      uint64 `json:"mem_free"`      // 系统可用内存（字节），Linux下为MemAvailable
+	DiskPath     string `json:"disk_path"`     // 统计磁盘空间的路径
+	DiskTotal    uint64 `json:"disk_total"`    // 磁盘总空间（字节）
+	DiskFree     uint64 `json:"disk_free"`     // 磁盘可用空间（字节）
+}
+
+// GetResourceUsage 获取当前进程和系统的资源使用情况
+// 磁盘空间统计当前工作目录所在的磁盘，按系统尽力获取，不支持的平台对应字段为0
+func GetResourceUsage() (*ResourceUsage, error) {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	usage := &ResourceUsage{
+		GoHeapAlloc:  ms.HeapAlloc,
+		GoSys:        ms.Sys,
+		NumGoroutine: runtime.NumGoroutine(),
+		ProcessRSS:   getProcessRSS(),
+	}
+	usage.MemTotal, usage.MemFree = getSystemMemory()
+
+	if wd, err := os.Getwd(); err == nil {
+		usage.DiskPath = wd
+		usage.DiskTotal, usage.DiskFree, _ = GetDiskUsage(wd)
+	}
+
+	return usage, nil
+}
+
+// getSystemMemory 获取系统内存总量和可用内存（字节）
+func getSystemMemory() (total, free uint64) {
+	switch runtime.GOOS {
+	case "linux":
+		content, err := os.ReadFile("/proc/meminfo")
+		if err != nil {
+			return 0, 0
+		}
+		total = parseKBLine(string(content), "MemTotal:")
+		free = parseKBLine(string(content), "MemAvailable:")
+		if free == 0 {
+			free = parseKBLine(string(content), "MemFree:")
+		}
+		return total, free
+	case "windows":
+		out, err := exec.Command("wmic", "OS", "get", "FreePhysicalMemory,TotalVisibleMemorySize", "/value").Output()
+		if err != nil {
+			return 0, 0
+		}
+		values := parseWmicValues(string(out))
+		total, _ = strconv.ParseUint(values["TotalVisibleMemorySize"], 10, 64)
+		free, _ = strconv.ParseUint(values["FreePhysicalMemory"], 10, 64)
+		return total * 1024, free * 1024
+	case "darwin":
+		if out, err := exec.Command("sysctl", "-n", "hw.memsize").Output(); err == nil {
+			total, _ = strconv.ParseUint(strings.TrimSpace(string(out)), 10, 64)
+		}
+		// vm_stat 首行包含页大小，如 "(page size of 16384 bytes)"
+		out, err := exec.Command("vm_stat").Output()
+		if err != nil {
+			return total, 0
+		}
+		lines := strings.Split(string(out), "\n")
+		pageSize := uint64(4096)
+		if m := regexp.MustCompile(`page size of (\d+) bytes`).FindStringSubmatch(lines[0]); len(m) == 2 {
+			pageSize, _ = strconv.ParseUint(m[1], 10, 64)
+		}
+		for _, line := range lines {
+			if strings.HasPrefix(line, "Pages free:") || strings.HasPrefix(line, "Pages inactive:") {
+				pages, _ := strconv.ParseUint(strings.TrimSuffix(strings.TrimSpace(strings.SplitN(line, ":", 2)[1]), "."), 10, 64)
+				free += pages * pageSize
+			}
+		}
+		return total, free
+	default:
+		return 0, 0
+	}
+}
+
+// parseKBLine 从 /proc 文件中解析形如 "MemTotal:  16384 kB" 的行，返回字节数
+func parseKBLine(content, key string) uint64 {
+	for _, line := range strings.Split(content, "\n") {
+		if !strings.HasPrefix(line, key) {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, key))
+		if len(fields) == 0 {
+			return 0
+		}
+		value, _ := strconv.ParseUint(fields[0], 10, 64)
+		return value * 1024
+	}
+	return 0
+}
+
+// parseWmicValues 解析 wmic /value 输出的 Key=Value 行
+func parseWmicValues(out string) map[string]string {
+	values := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(parts) == 2 {
+			values[parts[0]] = strings.TrimSpace(parts[1])
+		}
+	}
+	return values
+}
+
 // ========== 网络工具 ==========
 
 // DownloadFile 下载文件
+// 先写入同目录下的临时文件 <filepath>.<随机串>.tmp，下载完成后再重命名为目标文件，中断时不会留下不完整的文件
 func DownloadFile(url, filepath string) error {
-	// 创建目标文件
-	out, err := os.Create(filepath)
+	return DownloadFileChecksum(url, filepath, "")
+}
+
+// DownloadFileChecksum 下载文件并校验SHA256
+// expectedSHA256 为十六进制摘要，为空时不校验；校验失败时删除临时文件，目标文件保持不变
+func DownloadFileChecksum(url, filepath, expectedSHA256 string) error {
+	return downloadFile(context.Background(), http.DefaultClient, url, filepath, expectedSHA256)
+}
+
+// downloadFile 下载文件到临时文件，校验通过后原子重命名为目标文件
+func downloadFile(ctx context.Context, client *http.Client, url, dst, expectedSHA256 string) (err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return err
 	}
-	defer out.Close()
 
 	// 发起HTTP请求
-	resp, err := http.Get(url)
+	resp, err := client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -578,9 +826,154 @@ func DownloadFile(url, filepath string) error {
 		return fmt.Errorf("bad status: %s", resp.Status)
 	}
 
-	// 写入文件
-	_, err = io.Copy(out, resp.Body)
-	return err
+	// 写入同目录下的唯一临时文件，同一路径的并发下载互不干扰；任何失败都删除临时文件
+	out, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := out.Name()
+	defer func() {
+		if err != nil {
+			out.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	hasher := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(out, hasher), resp.Body); err != nil {
+		return err
+	}
+	// CreateTemp 创建的文件权限为0600，改为常规的0644
+	if err = out.Chmod(0644); err != nil {
+		return err
+	}
+	if err = out.Close(); err != nil {
+		return err
+	}
+
+	if expectedSHA256 != "" {
+		actual := hex.EncodeToString(hasher.Sum(nil))
+		if !strings.EqualFold(actual, strings.TrimSpace(expectedSHA256)) {
+			err = fmt.Errorf("checksum mismatch: expected %s, got %s", expectedSHA256, actual)
+			return err
+		}
+	}
+
+	return os.Rename(tmpPath, dst)
+}
+
+// DownloadJob 批量下载任务
+type DownloadJob struct {
+	URL            string `json:"url"`             // 下载地址
+	Path           string `json:"path"`            // 保存路径
+	ExpectedSHA256 string `json:"expected_sha256"` // 可选的SHA256校验值
+}
+
+// DownloadFiles 并发下载多个文件
+// 最多同时进行 concurrency 个下载，所有任务共用一个 http.Client
+// 返回的错误切片与 jobs 一一对应，nil 表示成功；单个任务失败不影响其他任务
+// ctx 取消后尚未开始的任务直接返回 ctx 的错误，进行中的请求会被中断
+func DownloadFiles(ctx context.Context, jobs []DownloadJob, concurrency int) []error {
+	errs := make([]error, len(jobs))
+	if len(jobs) == 0 {
+		return errs
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	if concurrency > len(jobs) {
+		concurrency = len(jobs)
+	}
+
+	client := &http.Client{}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				job := jobs[i]
+				errs[i] = downloadFile(ctx, client, job.URL, job.Path, job.ExpectedSHA256)
+			}
+		}()
+	}
+
+	for i := range jobs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return errs
+}
+
+// probeTimeout MeasureEndpoints 单次探测的超时时间
+const probeTimeout = 5 * time.Second
+
+// EndpointResult 端点探测结果
+type EndpointResult struct {
+	URL        string        `json:"url"`         // 探测地址
+	Latency    time.Duration `json:"latency"`     // 从发起请求到读完响应体的耗时
+	StatusCode int           `json:"status_code"` // HTTP状态码，请求失败时为0
+	Err        error         `json:"-"`           // 请求失败的原因，成功时为nil
+}
+
+// MeasureEndpoints 并发探测多个HTTP端点的延迟
+// 每个端点发起一次 GET 请求，单次探测超时为 probeTimeout
+// 结果按延迟升序排列，请求失败的端点排在最后；任何HTTP状态码都视为可达，由调用方按 StatusCode 判断
+func MeasureEndpoints(ctx context.Context, urls []string) []EndpointResult {
+	results := make([]EndpointResult, len(urls))
+	client := &http.Client{Timeout: probeTimeout}
+
+	var wg sync.WaitGroup
+	for i, u := range urls {
+		wg.Add(1)
+		go func(i int, u string) {
+			defer wg.Done()
+			results[i] = probeEndpoint(ctx, client, u)
+		}(i, u)
+	}
+	wg.Wait()
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if (results[i].Err == nil) != (results[j].Err == nil) {
+			return results[i].Err == nil
+		}
+		return results[i].Latency < results[j].Latency
+	})
+	return results
+}
+
+// probeEndpoint 探测单个端点
+func probeEndpoint(ctx context.Context, client *http.Client, rawurl string) EndpointResult {
+	result := EndpointResult{URL: rawurl}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawurl, nil)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Latency = time.Since(start)
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		result.Err = err
+	}
+	result.Latency = time.Since(start)
+	result.StatusCode = resp.StatusCode
+	return result
 }
 
 // CheckPort 检查端口是否可用
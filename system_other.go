@@ -0,0 +1,18 @@
+//go:build !(linux || darwin || freebsd || windows)
+
+package utils
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// GetDiskUsage 获取指定路径所在磁盘的总空间和可用空间（字节），当前平台不支持
+func GetDiskUsage(path string) (total, free uint64, err error) {
+	return 0, 0, fmt.Errorf("unsupported os: %s", runtime.GOOS)
+}
+
+// getProcessRSS 获取当前进程的常驻内存（字节），当前平台不支持时返回0
+func getProcessRSS() uint64 {
+	return 0
+}
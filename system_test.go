@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDownloadFilesSamePath(t *testing.T) {
+	body := strings.Repeat("0123456789", 10000)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	dst := filepath.Join(dir, "file.bin")
+	jobs := make([]DownloadJob, 8)
+	for i := range jobs {
+		jobs[i] = DownloadJob{URL: srv.URL, Path: dst}
+	}
+
+	for i, err := range DownloadFiles(context.Background(), jobs, len(jobs)) {
+		if err != nil {
+			t.Fatalf("job %d: %v", i, err)
+		}
+	}
+	content, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != body {
+		t.Fatalf("downloaded %d bytes, want %d", len(content), len(body))
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("temp files left behind: %d entries in %s", len(entries), dir)
+	}
+}
+
+func TestGetDiskUsage(t *testing.T) {
+	total, free, err := GetDiskUsage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total == 0 || free > total {
+		t.Fatalf("GetDiskUsage = (%d, %d), want total > 0 and free <= total", total, free)
+	}
+
+	if _, _, err := GetDiskUsage(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("GetDiskUsage on missing path: want error")
+	}
+}
+
+func TestGetProcessRSS(t *testing.T) {
+	if rss := getProcessRSS(); rss == 0 {
+		t.Fatal("getProcessRSS = 0")
+	}
+}
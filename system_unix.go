@@ -0,0 +1,41 @@
+//go:build linux || darwin || freebsd
+
+package utils
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+)
+
+// GetDiskUsage 获取指定路径所在磁盘的总空间和可用空间（字节）
+// 可用空间为非特权用户可用的部分，不含保留块
+func GetDiskUsage(path string) (total, free uint64, err error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, 0, err
+	}
+	return uint64(st.Blocks) * uint64(st.Bsize), uint64(st.Bavail) * uint64(st.Bsize), nil
+}
+
+// getProcessRSS 获取当前进程的常驻内存（字节）
+// Linux 读取 /proc/self/status 的当前值；darwin 与 freebsd 无需 cgo 只能取得峰值，使用 getrusage 的 ru_maxrss
+func getProcessRSS() uint64 {
+	if runtime.GOOS == "linux" {
+		content, err := os.ReadFile("/proc/self/status")
+		if err != nil {
+			return 0
+		}
+		return parseKBLine(string(content), "VmRSS:")
+	}
+
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil || ru.Maxrss < 0 {
+		return 0
+	}
+	// ru_maxrss 在 darwin 上以字节为单位，freebsd 上以KB为单位
+	if runtime.GOOS == "darwin" {
+		return uint64(ru.Maxrss)
+	}
+	return uint64(ru.Maxrss) * 1024
+}
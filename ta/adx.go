@@ -18,10 +18,11 @@ import (
 //	- ADX上升表示趋势增强
 //	- ADX下降表示趋势减弱
 type TaADX struct {
-	ADX     []float64 `json:"adx"`      // ADX值序列
-	PlusDI  []float64 `json:"plus_di"`  // +DI值序列
-	MinusDI []float64 `json:"minus_di"` // -DI值序列
-	Period  int       `json:"period"`   // 计算周期
+	ADX       []float64 `json:"adx"`        // ADX值序列
+	PlusDI    []float64 `json:"plus_di"`    // +DI值序列
+	MinusDI   []float64 `json:"minus_di"`   // -DI值序列
+	Period    int       `json:"period"`     // 计算周期
+	ValidFrom int       `json:"valid_from"` // ADX首个有效值的索引（period*2），+DI/-DI从period开始有效
 }
 
 // CalculateADX 计算平均趋向指标
@@ -52,7 +53,7 @@ type TaADX struct {
 //
 //	adx, err := CalculateADX(klineData, 14)
 func CalculateADX(klineData KlineDatas, period int) (*TaADX, error) {
-	if len(klineData) < period {
+	if period <= 0 || len(klineData) <= period {
 		return nil, fmt.Errorf("计算数据不足")
 	}
 
@@ -129,10 +130,11 @@ func CalculateADX(klineData KlineDatas, period int) (*TaADX, error) {
 	}
 
 	return &TaADX{
-		ADX:     adx,
-		PlusDI:  plusDI,
-		MinusDI: minusDI,
-		Period:  period,
+		ADX:       adx,
+		PlusDI:    plusDI,
+		MinusDI:   minusDI,
+		Period:    period,
+		ValidFrom: period * 2,
 	}, nil
 }
 
@@ -187,6 +189,13 @@ func (t *TaADX) Value() (adx, plusDI, minusDI float64) {
 	return t.ADX[lastIndex], t.PlusDI[lastIndex], t.MinusDI[lastIndex]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaADX) ContainsInvalid() bool {
+	return containsInvalid(t.ADX, t.PlusDI, t.MinusDI)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
@@ -209,15 +218,5 @@ func (t *TaADX) Value() (adx, plusDI, minusDI float64) {
 //   - -1: +DI下穿-DI（卖出信号）
 //   - 0: 无交叉信号
 func (t *TaADX) CrossOver() int {
-	if len(t.PlusDI) < 2 || len(t.MinusDI) < 2 {
-		return 0
-	}
-	lastIndex := len(t.PlusDI) - 1
-	if t.PlusDI[lastIndex-1] < t.MinusDI[lastIndex-1] && t.PlusDI[lastIndex] > t.MinusDI[lastIndex] {
-		return 1
-	} else if t.PlusDI[lastIndex-1] > t.MinusDI[lastIndex-1] && t.PlusDI[lastIndex] < t.MinusDI[lastIndex] {
-		return -1
-	} else {
-		return 0
-	}
+	return Cross(t.PlusDI, t.MinusDI)
 }
@@ -0,0 +1,139 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// TaAroon 表示阿隆指标(Aroon)的计算结果
+// 说明：
+//
+//	Aroon通过最高价、最低价距今的K线数衡量趋势的新旧程度：
+//	1. Up：周期内最高价出现得越近，数值越接近100
+//	2. Down：周期内最低价出现得越近，数值越接近100
+//	3. Oscillator：Up - Down，取值范围-100到100
+//	特点：
+//	- Up持续高于70且Down低于30表示强势上涨趋势
+//	- Up与Down交叉往往预示趋势切换
+//	- 两者同时处于低位表示盘整，适合与ADX搭配判断趋势强度
+type TaAroon struct {
+	Up         []float64 `json:"up"`         // Aroon Up序列
+	Down       []float64 `json:"down"`       // Aroon Down序列
+	Oscillator []float64 `json:"oscillator"` // Aroon振荡器序列（Up - Down）
+	Period     int       `json:"period"`     // 计算周期
+	ValidFrom  int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
+}
+
+// CalculateAroon 计算阿隆指标及阿隆振荡器
+// 说明：
+//
+//	计算窗口为包含当前K线在内的最近period根K线：
+//	Up = (period - 1 - 最高价距今K线数) / (period - 1) * 100
+//	Down = (period - 1 - 最低价距今K线数) / (period - 1) * 100
+//	Oscillator = Up - Down
+//	窗口内有多个相同的最高价（最低价）时取最近的一个
+//
+// 参数：
+//   - klineData: K线数据
+//   - period: 计算周期，至少为2，通常为25
+//
+// 返回值：
+//   - *TaAroon: 包含Aroon计算结果的结构体指针
+//   - error: 计算过程中的错误，如数据不足等
+//
+// 示例：
+//
+//	aroon, err := CalculateAroon(klineData, 25)
+func CalculateAroon(klineData KlineDatas, period int) (*TaAroon, error) {
+	if period < 2 {
+		return nil, fmt.Errorf("Aroon周期至少为2")
+	}
+	length := len(klineData)
+	if length < period {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	slices := preallocateSlices(length, 3)
+	up, down, oscillator := slices[0], slices[1], slices[2]
+
+	span := float64(period - 1)
+	for i := period - 1; i < length; i++ {
+		highIdx, lowIdx := i, i
+		for j := i - 1; j > i-period; j-- {
+			if klineData[j].High > klineData[highIdx].High {
+				highIdx = j
+			}
+			if klineData[j].Low < klineData[lowIdx].Low {
+				lowIdx = j
+			}
+		}
+		up[i] = (span - float64(i-highIdx)) / span * 100
+		down[i] = (span - float64(i-lowIdx)) / span * 100
+		oscillator[i] = up[i] - down[i]
+	}
+
+	return &TaAroon{
+		Up:         up,
+		Down:       down,
+		Oscillator: oscillator,
+		Period:     period,
+		ValidFrom:  period - 1,
+	}, nil
+}
+
+// Aroon 为K线数据计算阿隆指标
+// 参数：
+//   - period: 计算周期
+//
+// 返回值：
+//   - *TaAroon: 包含Aroon计算结果的结构体指针
+//   - error: 计算过程中的错误
+func (k *KlineDatas) Aroon(period int) (*TaAroon, error) {
+	return CalculateAroon(*k, period)
+}
+
+// Aroon_ 获取最新的Aroon值
+// 参数：
+//   - period: 计算周期
+//
+// 返回值：
+//   - float64: 最新的Aroon Up值
+//   - float64: 最新的Aroon Down值
+//   - float64: 最新的Aroon振荡器值
+func (k *KlineDatas) Aroon_(period int) (float64, float64, float64) {
+	aroon, err := k.Aroon(period)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return aroon.Value()
+}
+
+// Value 获取最新的Aroon值
+// 说明：
+//
+//	返回最新的Up、Down和振荡器值
+//	使用建议：
+//	- 振荡器大于0表示上涨趋势占优，小于0表示下跌趋势占优
+//	- Up上穿Down可视为趋势转多的信号
+//	- Up与Down同时低于50时市场可能处于盘整
+//
+// 返回值：
+//   - up: 最新的Aroon Up值
+//   - down: 最新的Aroon Down值
+//   - oscillator: 最新的Aroon振荡器值
+func (t *TaAroon) Value() (up, down, oscillator float64) {
+	lastIndex := len(t.Up) - 1
+	return t.Up[lastIndex], t.Down[lastIndex], t.Oscillator[lastIndex]
+}
+
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaAroon) ContainsInvalid() bool {
+	return containsInvalid(t.Up, t.Down, t.Oscillator)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -20,6 +20,7 @@ type TaATR struct {
 	Values    []float64 `json:"values"`     // ATR值序列
 	Period    int       `json:"period"`     // 计算周期
 	TrueRange []float64 `json:"true_range"` // 真实波幅序列
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
 }
 
 // CalculateATR 计算平均真实波幅
@@ -48,7 +49,7 @@ type TaATR struct {
 //
 //	atr, err := CalculateATR(klineData, 14)
 func CalculateATR(klineData KlineDatas, period int) (*TaATR, error) {
-	if len(klineData) < period {
+	if period <= 0 || len(klineData) <= period {
 		return nil, fmt.Errorf("计算数据不足")
 	}
 
@@ -82,6 +83,7 @@ func CalculateATR(klineData KlineDatas, period int) (*TaATR, error) {
 		Values:    atr,
 		Period:    period,
 		TrueRange: trueRange,
+		ValidFrom: period,
 	}, nil
 }
 
@@ -129,6 +131,13 @@ func (t *TaATR) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaATR) ContainsInvalid() bool {
+	return containsInvalid(t.Values, t.TrueRange)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
@@ -155,3 +164,42 @@ func (t *TaATR) Percent(currentPrice float64) float64 {
 	}
 	return t.Value() / currentPrice * 100
 }
+
+// PositionSize 根据ATR止损距离和账户风险计算开仓数量
+// 说明：
+//
+//	止损设置在 entryPrice ± stopMultiplier * ATR，使止损触发时亏损恰好为账户权益的 riskPct%：
+//	数量 = accountEquity * riskPct / 100 / (stopMultiplier * ATR * contractMultiplier)
+//	默认每点价值为1，即线性USDT合约或现货，数量单位为币；
+//	其他合约可通过 contractMultiplier 传入每张合约每点的价值，此时数量单位为张
+//	返回值未按交易所的数量精度取整，下单前需按品种步长截断
+//
+// 参数：
+//   - accountEquity: 账户权益
+//   - riskPct: 单笔风险占权益的百分比，如1表示1%
+//   - stopMultiplier: 止损距离的ATR倍数，通常为1.5-3
+//   - entryPrice: 开仓价格
+//   - contractMultiplier: 可选的合约乘数，默认为1
+//
+// 返回值：
+//   - float64: 开仓数量，参数无效或ATR为0时返回0
+//
+// 示例：
+//
+//	atr, _ := klines.ATR(14)
+//	qty := atr.PositionSize(10000, 1, 2, klines.GetLast("close"))
+func (t *TaATR) PositionSize(accountEquity, riskPct, stopMultiplier, entryPrice float64, contractMultiplier ...float64) float64 {
+	multiplier := 1.0
+	if len(contractMultiplier) > 0 && contractMultiplier[0] > 0 {
+		multiplier = contractMultiplier[0]
+	}
+	if accountEquity <= 0 || riskPct <= 0 || stopMultiplier <= 0 || entryPrice <= 0 || len(t.Values) == 0 {
+		return 0
+	}
+
+	stopDistance := stopMultiplier * t.Value()
+	if stopDistance <= 0 {
+		return 0
+	}
+	return accountEquity * riskPct / 100 / (stopDistance * multiplier)
+}
@@ -17,9 +17,10 @@ import (
 //	- 轨道宽度反映市场波动性
 //	- 可用于判断超买超卖和趋势强度
 type TaBoll struct {
-	Upper []float64 `json:"upper"` // 上轨线序列
-	Mid   []float64 `json:"mid"`   // 中轨线序列（移动平均线）
-	Lower []float64 `json:"lower"` // 下轨线序列
+	Upper     []float64 `json:"upper"`      // 上轨线序列
+	Mid       []float64 `json:"mid"`        // 中轨线序列（移动平均线）
+	Lower     []float64 `json:"lower"`      // 下轨线序列
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
 }
 
 // CalculateBoll 计算布林带指标
@@ -91,9 +92,10 @@ func CalculateBoll(prices []float64, period int, stdDev float64) (*TaBoll, error
 	}
 
 	return &TaBoll{
-		Upper: upper,
-		Mid:   mid,
-		Lower: lower,
+		Upper:     upper,
+		Mid:       mid,
+		Lower:     lower,
+		ValidFrom: period - 1,
 	}, nil
 }
 
@@ -155,6 +157,13 @@ func (t *TaBoll) Value() (upper, mid, lower float64) {
 	return t.Upper[lastIndex], t.Mid[lastIndex], t.Lower[lastIndex]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaBoll) ContainsInvalid() bool {
+	return containsInvalid(t.Upper, t.Mid, t.Lower)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
@@ -18,7 +18,8 @@ import (
 //	- -100以下为超卖区
 //	- 数值的绝对值越大，价格偏离度越高
 type TaCCI struct {
-	Values []float64 `json:"values"` // CCI值序列
+	Values    []float64 `json:"values"`     // CCI值序列
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
 }
 
 // CalculateCCI 计算商品通道指标
@@ -73,13 +74,12 @@ func CalculateCCI(klineData KlineDatas, period int) (*TaCCI, error) {
 		}
 		meanDeviation := sumAbsDev / float64(period)
 
-		if meanDeviation != 0 {
-			cci[i] = (typicalPrice[i] - smaTP) / (0.015 * meanDeviation)
-		}
+		cci[i] = safeDivide(typicalPrice[i]-smaTP, 0.015*meanDeviation, 0)
 	}
 
 	return &TaCCI{
-		Values: cci,
+		Values:    cci,
+		ValidFrom: period - 1,
 	}, nil
 }
 
@@ -129,6 +129,62 @@ func (t *TaCCI) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaCCI) ContainsInvalid() bool {
+	return containsInvalid(t.Values)
+}
+
+// Signal 检测CCI突破超买超卖阈值的信号
+// 说明：
+//
+//	比较最后两个有效值：
+//	- 由下向上穿越 +threshold 时返回1，趋势转强
+//	- 由上向下穿越 -threshold 时返回-1，趋势转弱
+//	预热区内的值不参与判断
+//
+// 参数：
+//   - threshold: 可选的阈值，默认为100，传入小于等于0的值时使用默认值
+//
+// 返回值：
+//   - 1: CCI上穿+threshold
+//   - -1: CCI下穿-threshold
+//   - 0: 无信号
+func (t *TaCCI) Signal(threshold ...float64) int {
+	level := 100.0
+	if len(threshold) > 0 && threshold[0] > 0 {
+		level = threshold[0]
+	}
+
+	lastIndex := len(t.Values) - 1
+	if lastIndex < 1 || lastIndex-1 < t.ValidFrom {
+		return 0
+	}
+	last := t.Values[lastIndex-1:]
+	if CrossOver(last, []float64{level, level}) == 1 {
+		return 1
+	}
+	return CrossUnder(last, []float64{-level, -level})
+}
+
+// ZeroCross 检测CCI穿越0轴的信号
+// 说明：
+//
+//	比较最后两个有效值，预热区内的值不参与判断
+//
+// 返回值：
+//   - 1: CCI上穿0轴
+//   - -1: CCI下穿0轴
+//   - 0: 无信号
+func (t *TaCCI) ZeroCross() int {
+	lastIndex := len(t.Values) - 1
+	if lastIndex < 1 || lastIndex-1 < t.ValidFrom {
+		return 0
+	}
+	return Cross(t.Values[lastIndex-1:], []float64{0, 0})
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
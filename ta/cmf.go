@@ -17,8 +17,9 @@ import (
 //	- 负值表示资金流出（看空）
 //	- 绝对值越大表示资金流动越强烈
 type TaCMF struct {
-	Values []float64 `json:"values"` // CMF值序列
-	Period int       `json:"period"` // 计算周期
+	Values    []float64 `json:"values"`     // CMF值序列
+	Period    int       `json:"period"`     // 计算周期
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
 }
 
 // CalculateCMF 计算钱德动量指标
@@ -78,14 +79,13 @@ func CalculateCMF(high, low, close, volume []float64, period int) (*TaCMF, error
 			sumMFV += mfv[i-j]
 			sumVolume += volume[i-j]
 		}
-		if sumVolume != 0 {
-			cmf[i] = sumMFV / sumVolume
-		}
+		cmf[i] = safeDivide(sumMFV, sumVolume, 0)
 	}
 
 	return &TaCMF{
-		Values: cmf,
-		Period: period,
+		Values:    cmf,
+		Period:    period,
+		ValidFrom: period - 1,
 	}, nil
 }
 
@@ -153,6 +153,13 @@ func (t *TaCMF) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaCMF) ContainsInvalid() bool {
+	return containsInvalid(t.Values)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
@@ -0,0 +1,134 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// TaCMO 表示钱德动量摆动指标(Chande Momentum Oscillator)的计算结果
+// 说明：
+//
+//	CMO由Tushar Chande提出，衡量周期内上涨动量与下跌动量的差异：
+//	CMO = (上涨幅度之和 - 下跌幅度之和) / (上涨幅度之和 + 下跌幅度之和) * 100
+//	特点：
+//	- 取值范围为-100到100
+//	- 与RSI类似，但直接使用未平滑的涨跌幅，对价格变化更敏感
+//	- 大于50通常视为超买，小于-50通常视为超卖
+type TaCMO struct {
+	Values    []float64 `json:"values"`     // CMO值序列
+	Period    int       `json:"period"`     // 计算周期
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
+}
+
+// CalculateCMO 计算钱德动量摆动指标
+// 说明：
+//
+//	计算步骤：
+//	1. 计算每根K线相对前一根的价格变化
+//	2. 在最近period个变化中分别累计上涨幅度与下跌幅度
+//	3. CMO = (上涨之和 - 下跌之和) / (上涨之和 + 下跌之和) * 100
+//	周期内价格没有变化时CMO为0
+//
+// 参数：
+//   - prices: 价格序列
+//   - period: 计算周期，通常为9-20
+//
+// 返回值：
+//   - *TaCMO: 包含CMO计算结果的结构体指针
+//   - error: 计算过程中的错误，如数据不足等
+//
+// 示例：
+//
+//	cmo, err := CalculateCMO(prices, 14)
+func CalculateCMO(prices []float64, period int) (*TaCMO, error) {
+	length := len(prices)
+	if period <= 0 || length <= period {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	values := make([]float64, length)
+
+	var up, down float64
+	for i := 1; i < length; i++ {
+		// 加入新的价格变化
+		if change := prices[i] - prices[i-1]; change > 0 {
+			up += change
+		} else {
+			down -= change
+		}
+		// 移出窗口外的价格变化
+		if i > period {
+			if change := prices[i-period] - prices[i-period-1]; change > 0 {
+				up -= change
+			} else {
+				down += change
+			}
+		}
+		if i >= period {
+			values[i] = safeDivide(up-down, up+down, 0) * 100
+		}
+	}
+
+	return &TaCMO{
+		Values:    values,
+		Period:    period,
+		ValidFrom: period,
+	}, nil
+}
+
+// CMO 为K线数据计算钱德动量摆动指标
+// 参数：
+//   - period: 计算周期
+//   - source: 价格类型，支持"open"、"high"、"low"、"close"
+//
+// 返回值：
+//   - *TaCMO: 包含CMO计算结果的结构体指针
+//   - error: 计算过程中的错误
+func (k *KlineDatas) CMO(period int, source string) (*TaCMO, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateCMO(prices, period)
+}
+
+// CMO_ 获取最新的CMO值
+// 参数：
+//   - period: 计算周期
+//   - source: 价格类型
+//
+// 返回值：
+//   - float64: 最新的CMO值
+func (k *KlineDatas) CMO_(period int, source string) float64 {
+	cmo, err := k.CMO(period, source)
+	if err != nil {
+		return 0
+	}
+	return cmo.Value()
+}
+
+// Value 获取最新的CMO值
+// 说明：
+//
+//	返回CMO的最新值
+//	使用建议：
+//	- 大于50视为超买，小于-50视为超卖
+//	- 上穿0轴视为动量转强，下穿0轴视为动量转弱
+//
+// 返回值：
+//   - float64: 最新的CMO值
+func (t *TaCMO) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaCMO) ContainsInvalid() bool {
+	return containsInvalid(t.Values)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
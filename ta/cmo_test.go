@@ -0,0 +1,44 @@
+package ta
+
+import "testing"
+
+func TestCalculateCMOHandComputed(t *testing.T) {
+	// 涨跌: +1, -0.5, +1, +0.5, -1
+	// i=3: (2-0.5)/(2+0.5)=60%  i=4: (1.5-0.5)/(1.5+0.5)=50%  i=5: (1.5-1)/(1.5+1)=20%
+	cmo, err := CalculateCMO([]float64{10, 11, 10.5, 11.5, 12, 11}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSeries(t, "CMO", cmo.Values, 3, []float64{60, 50, 20}, 1e-9)
+	if cmo.Value() != 20 {
+		t.Errorf("Value() = %v, want 20", cmo.Value())
+	}
+}
+
+func TestCalculateCMOReference(t *testing.T) {
+	// 按定义逐窗口求和计算的参考值，保留4位小数
+	want := []float64{
+		40.9283, 40.0419, 39.6624, 61.1354, 46.6667, 19.6126, 25.0564, 20.0000, -3.0445, 7.7568,
+		-2.0952, -12.2744, -24.5342, -35.4730, -34.5638, -23.7148, -36.5035, -49.8008, -39.5647,
+	}
+	cmo, err := CalculateCMO(stockChartsRSICloses, 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSeries(t, "CMO", cmo.Values, 14, want, 1e-3)
+
+	// CMO 与简单平均的RSI满足 CMO = 2*RSI - 100
+	rsi, err := CalculateRSI(stockChartsRSICloses, 14, RSISMA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 14; i < len(stockChartsRSICloses); i++ {
+		if diff := cmo.Values[i] - (2*rsi.Values[i] - 100); diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("index %d: CMO %.6f != 2*RSI-100 %.6f", i, cmo.Values[i], 2*rsi.Values[i]-100)
+		}
+	}
+
+	if _, err := CalculateCMO(stockChartsRSICloses[:14], 14); err == nil {
+		t.Error("expected error for insufficient data")
+	}
+}
@@ -0,0 +1,95 @@
+package ta
+
+import (
+	"math"
+)
+
+// CrossOver 检测序列a是否在最新一根K线上上穿序列b
+// 说明：
+//
+//	按末尾对齐比较两条序列的最后两个点：前一点 a <= b 且最新点 a > b 时视为上穿
+//	两条序列长度不同时以较短的为准从末尾对齐，任一点为NaN时不判定交叉
+//
+// 参数：
+//   - a: 快线序列，如MACD的DIF、KDJ的K
+//   - b: 慢线序列，如MACD的DEA、KDJ的D
+//
+// 返回值：
+//   - 1: a上穿b
+//   - 0: 无上穿或数据不足
+//
+// 示例：
+//
+//	if ta.CrossOver(macd.Dif, macd.Dea) == 1 {
+//		// 金叉
+//	}
+func CrossOver(a, b []float64) int {
+	prevA, prevB, currA, currB, ok := lastTwoAligned(a, b)
+	if ok && prevA <= prevB && currA > currB {
+		return 1
+	}
+	return 0
+}
+
+// CrossUnder 检测序列a是否在最新一根K线上下穿序列b
+// 说明：
+//
+//	按末尾对齐比较两条序列的最后两个点：前一点 a >= b 且最新点 a < b 时视为下穿
+//	两条序列长度不同时以较短的为准从末尾对齐，任一点为NaN时不判定交叉
+//
+// 参数：
+//   - a: 快线序列
+//   - b: 慢线序列
+//
+// 返回值：
+//   - -1: a下穿b
+//   - 0: 无下穿或数据不足
+func CrossUnder(a, b []float64) int {
+	prevA, prevB, currA, currB, ok := lastTwoAligned(a, b)
+	if ok && prevA >= prevB && currA < currB {
+		return -1
+	}
+	return 0
+}
+
+// Cross 检测序列a与序列b在最新一根K线上的交叉
+// 说明：
+//
+//	等价于 CrossOver(a, b) + CrossUnder(a, b)
+//
+// 参数：
+//   - a: 快线序列
+//   - b: 慢线序列
+//
+// 返回值：
+//   - 1: a上穿b
+//   - -1: a下穿b
+//   - 0: 无交叉或数据不足
+//
+// 示例：
+//
+//	signal := ta.Cross(kdj.K, kdj.D)
+func Cross(a, b []float64) int {
+	return CrossOver(a, b) + CrossUnder(a, b)
+}
+
+// lastTwoAligned 取两条序列末尾对齐后的最后两个点，数据不足或含NaN时ok为false
+func lastTwoAligned(a, b []float64) (prevA, prevB, currA, currB float64, ok bool) {
+	if len(a) < 2 || len(b) < 2 {
+		return 0, 0, 0, 0, false
+	}
+	prevA, currA = a[len(a)-2], a[len(a)-1]
+	prevB, currB = b[len(b)-2], b[len(b)-1]
+	for _, v := range [...]float64{prevA, prevB, currA, currB} {
+		if math.IsNaN(v) {
+			return 0, 0, 0, 0, false
+		}
+	}
+	return prevA, prevB, currA, currB, true
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,145 @@
+package ta
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// csvHeader CSV文件的表头，与 KlineData 的json标签一致
+var csvHeader = []string{"startTime", "open", "high", "low", "close", "volume"}
+
+// WriteCSV 将K线数据写出为CSV
+// 说明：
+//
+//	第一行为表头 startTime,open,high,low,close,volume，之后每根K线一行
+//	价格使用最短的十进制表示，读回后与原值完全一致，可直接被pandas等工具读取
+//
+// 参数：
+//   - w: 写入目标
+//
+// 返回值：
+//   - error: 写入过程中的错误
+//
+// 示例：
+//
+//	f, _ := os.Create("btc_1h.csv")
+//	defer f.Close()
+//	err := klines.WriteCSV(f)
+func (k KlineDatas) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	row := make([]string, len(csvHeader))
+	for i, kline := range k {
+		if kline == nil {
+			return fmt.Errorf("第%d根K线为空", i+1)
+		}
+		row[0] = strconv.FormatInt(kline.StartTime, 10)
+		row[1] = strconv.FormatFloat(kline.Open, 'f', -1, 64)
+		row[2] = strconv.FormatFloat(kline.High, 'f', -1, 64)
+		row[3] = strconv.FormatFloat(kline.Low, 'f', -1, 64)
+		row[4] = strconv.FormatFloat(kline.Close, 'f', -1, 64)
+		row[5] = strconv.FormatFloat(kline.Volume, 'f', -1, 64)
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadKlineCSV 从CSV读取K线数据
+// 说明：
+//
+//	列顺序固定为 startTime,open,high,low,close,volume，多余的列会被忽略
+//	第一行如果不是数字则视为表头跳过，因此有无表头均可
+//	时间列支持整数和浮点数写法，与 NewKlineDatas 一致统一换算为毫秒，默认按位数自动识别秒/毫秒/微秒/纳秒
+//
+// 参数：
+//   - r: 读取来源
+//   - unit: 可选，时间列的单位，默认 TimeUnitAuto
+//
+// 返回值：
+//   - KlineDatas: 读取到的K线数据
+//   - error: 格式错误或没有数据时返回错误
+//
+// 示例：
+//
+//	f, _ := os.Open("btc_1h.csv")
+//	defer f.Close()
+//	klines, err := ta.ReadKlineCSV(f)
+//	klines, err := ta.ReadKlineCSV(f, ta.TimeUnitSecond)
+func ReadKlineCSV(r io.Reader, unit ...TimeUnit) (KlineDatas, error) {
+	timeUnit := TimeUnitAuto
+	if len(unit) > 0 {
+		timeUnit = unit[0]
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var result KlineDatas
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("读取第%d行失败: %v", line, err)
+		}
+		if len(record) < len(csvHeader) {
+			return nil, fmt.Errorf("第%d行列数不足，需要至少%d列，当前只有%d列", line, len(csvHeader), len(record))
+		}
+
+		startTime, err := parseCSVTime(record[0], timeUnit)
+		if err != nil {
+			if line == 1 {
+				// 首行无法解析为时间，视为表头
+				continue
+			}
+			return nil, fmt.Errorf("第%d行时间字段转换失败: %v", line, err)
+		}
+
+		var values [5]float64
+		for i := range values {
+			if values[i], err = strconv.ParseFloat(strings.TrimSpace(record[i+1]), 64); err != nil {
+				return nil, fmt.Errorf("第%d行%s字段转换失败: %v", line, csvHeader[i+1], err)
+			}
+		}
+
+		result = append(result, &KlineData{
+			StartTime: startTime,
+			Open:      values[0],
+			High:      values[1],
+			Low:       values[2],
+			Close:     values[3],
+			Volume:    values[4],
+		})
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("没有K线数据")
+	}
+	return result, nil
+}
+
+// parseCSVTime 解析CSV中的时间戳并换算为毫秒，兼容整数和浮点数写法
+func parseCSVTime(s string, unit TimeUnit) (int64, error) {
+	s = strings.TrimSpace(s)
+	if t, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return unit.toMillis(t), nil
+	}
+	t, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return unit.floatToMillis(t), nil
+}
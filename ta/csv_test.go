@@ -0,0 +1,61 @@
+package ta
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadKlineCSVNormalizesTime(t *testing.T) {
+	const secondsCSV = `startTime,open,high,low,close,volume
+1700000000,1,2,0.5,1.5,10
+1700000060.5,1.5,2,1,1.8,12
+`
+	klines, err := ReadKlineCSV(strings.NewReader(secondsCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(klines) != 2 || klines[0].StartTime != 1700000000000 || klines[1].StartTime != 1700000060500 {
+		t.Fatalf("auto-detected seconds: got %d, %d", klines[0].StartTime, klines[1].StartTime)
+	}
+
+	// 显式单位：1e6 按自动识别会视为秒，指定为毫秒后保持不变
+	klines, err = ReadKlineCSV(strings.NewReader("1000000,1,1,1,1,1\n"), TimeUnitMillisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if klines[0].StartTime != 1000000 {
+		t.Fatalf("explicit millis: got %d", klines[0].StartTime)
+	}
+
+	klines, err = ReadKlineCSV(strings.NewReader("1700000000123456,1,1,1,1,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if klines[0].StartTime != 1700000000123 {
+		t.Fatalf("auto-detected micros: got %d", klines[0].StartTime)
+	}
+}
+
+func TestKlineCSVRoundTrip(t *testing.T) {
+	want := KlineDatas{
+		{StartTime: 1700000000000, Open: 0.1, High: 0.3, Low: 0.05, Close: 0.2, Volume: 123.456},
+		{StartTime: 1700000060000, Open: 0.2, High: 0.25, Low: 0.15, Close: 0.22, Volume: 7},
+	}
+	var buf bytes.Buffer
+	if err := want.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadKlineCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d klines, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if *got[i] != *want[i] {
+			t.Errorf("kline %d = %+v, want %+v", i, *got[i], *want[i])
+		}
+	}
+}
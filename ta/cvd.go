@@ -0,0 +1,140 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// Trade 表示一笔成交，通常来自交易所的逐笔成交或归集成交推送
+type Trade struct {
+	Price        float64 `json:"price"`          // 成交价格
+	Qty          float64 `json:"qty"`            // 成交数量
+	BuyerIsMaker bool    `json:"buyer_is_maker"` // 买方是否为挂单方，为true时表示主动卖出
+	Time         int64   `json:"time"`           // 成交时间戳（毫秒）
+}
+
+// TaCVD 表示累计成交量差(Cumulative Volume Delta)的计算结果
+// 说明：
+//
+//	CVD统计主动买入与主动卖出成交量之差的累计值：
+//	1. 买方为吃单方（BuyerIsMaker为false）时，成交量计为正
+//	2. 卖方为吃单方（BuyerIsMaker为true）时，成交量计为负
+//	3. 逐笔累加得到CVD序列
+//	特点：
+//	- 反映主动资金的买卖方向，属于订单流指标
+//	- 价格上涨而CVD下降时，上涨缺少主动买盘支撑
+//	- 与OBV不同，按逐笔成交的主动方向而非收盘价涨跌计量
+type TaCVD struct {
+	Values    []float64 `json:"values"`     // CVD值序列，与成交一一对应
+	Delta     []float64 `json:"delta"`      // 每笔成交的带符号成交量
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引（CVD无预热区，始终为0）
+}
+
+// CalculateCVD 计算累计成交量差
+// 参数：
+//   - trades: 按时间升序排列的成交序列
+//
+// 返回值：
+//   - *TaCVD: 包含CVD计算结果的结构体指针
+//   - error: 计算过程中的错误，如数据不足等
+//
+// 示例：
+//
+//	cvd, err := CalculateCVD(trades)
+func CalculateCVD(trades []Trade) (*TaCVD, error) {
+	if len(trades) == 0 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	slices := preallocateSlices(len(trades), 2)
+	values, delta := slices[0], slices[1]
+
+	var state CVDState
+	for i, trade := range trades {
+		delta[i] = tradeDelta(trade)
+		values[i] = state.Update(trade)
+	}
+
+	return &TaCVD{
+		Values: values,
+		Delta:  delta,
+	}, nil
+}
+
+// tradeDelta 返回单笔成交的带符号成交量，主动买入为正，主动卖出为负
+func tradeDelta(trade Trade) float64 {
+	if trade.BuyerIsMaker {
+		return -trade.Qty
+	}
+	return trade.Qty
+}
+
+// Value 获取最新的CVD值
+// 返回值：
+//   - float64: 最新的CVD值
+func (t *TaCVD) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaCVD) ContainsInvalid() bool {
+	return containsInvalid(t.Values, t.Delta)
+}
+
+// CVDState 增量计算CVD的状态
+// 说明：
+//
+//	用于实时成交推送，每收到一笔成交调用一次 Update，无需保存历史成交
+//	零值即可直接使用，非并发安全，多个goroutine使用时需自行加锁
+//
+// 示例：
+//
+//	var state ta.CVDState
+//	for trade := range trades {
+//		cvd := state.Update(trade)
+//	}
+type CVDState struct {
+	cvd      float64
+	lastTime int64
+	count    int
+}
+
+// Update 累加一笔成交并返回最新的CVD值
+// 参数：
+//   - trade: 新的成交
+//
+// 返回值：
+//   - float64: 累加后的CVD值
+func (s *CVDState) Update(trade Trade) float64 {
+	s.cvd += tradeDelta(trade)
+	s.lastTime = trade.Time
+	s.count++
+	return s.cvd
+}
+
+// Value 获取当前的CVD值
+func (s *CVDState) Value() float64 {
+	return s.cvd
+}
+
+// LastTime 获取最近一笔成交的时间戳（毫秒）
+func (s *CVDState) LastTime() int64 {
+	return s.lastTime
+}
+
+// Count 获取已累加的成交笔数
+func (s *CVDState) Count() int {
+	return s.count
+}
+
+// Reset 清空状态，用于按交易日或时段重新开始累计
+func (s *CVDState) Reset() {
+	*s = CVDState{}
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
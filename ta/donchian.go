@@ -0,0 +1,129 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaDonchian 表示唐奇安通道(Donchian Channels)的计算结果
+// 说明：
+//
+//	唐奇安通道由N周期内的最高价和最低价构成：
+//	1. 上轨：N周期最高价
+//	2. 下轨：N周期最低价
+//	3. 中轨：(上轨 + 下轨) / 2
+//	特点：
+//	- 海龟交易法则的核心指标
+//	- 价格突破上轨视为向上突破，跌破下轨视为向下突破
+//	- 常与ATR配合计算仓位和止损
+type TaDonchian struct {
+	Upper     []float64 `json:"upper"`      // 上轨线序列（N周期最高价）
+	Mid       []float64 `json:"mid"`        // 中轨线序列
+	Lower     []float64 `json:"lower"`      // 下轨线序列（N周期最低价）
+	Period    int       `json:"period"`     // 计算周期
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
+}
+
+// CalculateDonchian 计算唐奇安通道指标
+// 说明：
+//
+//	窗口包含当前K线，即索引i的上轨为 [i-period+1, i] 范围内的最高价
+//	判断突破时应与前一根K线的通道比较，否则当前K线的最高价不可能高于自身所在的上轨
+//
+// 参数：
+//   - klineData: K线数据
+//   - period: 计算周期，海龟法则常用20（入场）与10（离场）
+//
+// 返回值：
+//   - *TaDonchian: 包含唐奇安通道计算结果的结构体指针
+//   - error: 计算过程中的错误，如数据不足等
+//
+// 示例：
+//
+//	dc, err := CalculateDonchian(klineData, 20)
+func CalculateDonchian(klineData KlineDatas, period int) (*TaDonchian, error) {
+	length := len(klineData)
+	if period <= 0 || length < period {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	slices := preallocateSlices(length, 3)
+	upper, mid, lower := slices[0], slices[1], slices[2]
+
+	for i := period - 1; i < length; i++ {
+		highest, lowest := klineData[i].High, klineData[i].Low
+		for j := i - period + 1; j < i; j++ {
+			highest = math.Max(highest, klineData[j].High)
+			lowest = math.Min(lowest, klineData[j].Low)
+		}
+		upper[i] = highest
+		lower[i] = lowest
+		mid[i] = (highest + lowest) / 2
+	}
+
+	return &TaDonchian{
+		Upper:     upper,
+		Mid:       mid,
+		Lower:     lower,
+		Period:    period,
+		ValidFrom: period - 1,
+	}, nil
+}
+
+// Donchian 为K线数据计算唐奇安通道指标
+// 参数：
+//   - period: 计算周期
+//
+// 返回值：
+//   - *TaDonchian: 包含唐奇安通道计算结果的结构体指针
+//   - error: 计算过程中的错误
+func (k *KlineDatas) Donchian(period int) (*TaDonchian, error) {
+	return CalculateDonchian(*k, period)
+}
+
+// Donchian_ 获取最新的唐奇安通道值
+// 参数：
+//   - period: 计算周期
+//
+// 返回值：
+//   - float64: 最新的上轨值
+//   - float64: 最新的中轨值
+//   - float64: 最新的下轨值
+func (k *KlineDatas) Donchian_(period int) (float64, float64, float64) {
+	dc, err := k.Donchian(period)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return dc.Value()
+}
+
+// Value 获取最新的唐奇安通道值
+// 说明：
+//
+//	返回最新的上中下轨值
+//	使用建议：
+//	- 收盘价突破前一根K线的上轨可作为做多信号
+//	- 收盘价跌破前一根K线的下轨可作为做空信号
+//	- 较短周期的反向通道可作为离场位置
+//
+// 返回值：
+//   - upper: 上轨值
+//   - mid: 中轨值
+//   - lower: 下轨值
+func (t *TaDonchian) Value() (upper, mid, lower float64) {
+	lastIndex := len(t.Upper) - 1
+	return t.Upper[lastIndex], t.Mid[lastIndex], t.Lower[lastIndex]
+}
+
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaDonchian) ContainsInvalid() bool {
+	return containsInvalid(t.Upper, t.Mid, t.Lower)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
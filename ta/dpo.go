@@ -26,6 +26,7 @@ type TaDpo struct {
 	LongPeriod   int       `json:"long_period"`   // 长周期
 	XPeriod      int       `json:"x_period"`      // 差值长度周期
 	SmoothPeriod int       `json:"smooth_period"` // 平滑周期
+	ValidFrom    int       `json:"valid_from"`    // High/Low/Mid首个有效值的索引，Diff从ValidFrom-XPeriod-SmoothPeriod+2开始有效
 }
 
 // CalculateDPO 计算DPO指标
@@ -150,6 +151,10 @@ func CalculateDPO(prices []float64, shortPeriod, longPeriod, xPeriod, smoothPeri
 		return nil, err
 	}
 
+	// 差值从长周期DPO平滑完成后有效，最高/最低点还需再经过X周期窗口和一次平滑
+	diffValidFrom := longPeriod + offsetLong - 1 + smoothPeriod - 1
+	validFrom := diffValidFrom + xPeriod - 1 + smoothPeriod - 1
+
 	// 计算中间值
 	dpoDiffMid := make([]float64, length)
 	for i := 0; i < length; i++ {
@@ -167,6 +172,7 @@ func CalculateDPO(prices []float64, shortPeriod, longPeriod, xPeriod, smoothPeri
 		LongPeriod:   longPeriod,
 		XPeriod:      xPeriod,
 		SmoothPeriod: smoothPeriod,
+		ValidFrom:    validFrom,
 	}, nil
 }
 
@@ -231,3 +237,10 @@ func (t *TaDpo) Value() (short, long, diff, high, low, mid float64) {
 	lastIndex := len(t.Diff) - 1
 	return t.Short[lastIndex], t.Long[lastIndex], t.Diff[lastIndex], t.High[lastIndex], t.Low[lastIndex], t.Mid[lastIndex]
 }
+
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaDpo) ContainsInvalid() bool {
+	return containsInvalid(t.Short, t.Long, t.Diff, t.High, t.Low, t.Mid)
+}
@@ -17,8 +17,9 @@ import (
 //	- 平滑度介于SMA和WMA之间
 //	- 适合中短期趋势跟踪
 type TaEMA struct {
-	Values []float64 `json:"values"` // EMA值序列
-	Period int       `json:"period"` // 计算周期
+	Values    []float64 `json:"values"`     // EMA值序列
+	Period    int       `json:"period"`     // 计算周期
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
 }
 
 // CalculateEMA 计算指数移动平均线
@@ -34,6 +35,9 @@ type TaEMA struct {
 //	- 判断价格趋势
 //	- 支撑位和阻力位分析
 //	- 用于构建其他技术指标
+//	预热区间：
+//	- 前 period-1 个值为0（无效），首个有效值位于索引 period-1
+//	- 由于以SMA作为种子，有效值从首个位置起即无明显偏差
 //
 // 参数：
 //   - prices: 价格序列
@@ -50,7 +54,38 @@ type TaEMA struct {
 //
 //	ema, err := CalculateEMA(prices, 20)
 func CalculateEMA(prices []float64, period int) (*TaEMA, error) {
-	if len(prices) < period {
+	return CalculateEMAFrom(prices, period, 0)
+}
+
+// CalculateEMAFrom 从指定起始位置计算指数移动平均线
+// 说明：
+//
+//	用于输入序列前部存在无效预热值（如0填充）的场景，避免无效值参与种子计算：
+//	1. 以 prices[start : start+period] 的SMA作为首个EMA值
+//	2. 之后按标准EMA公式递推
+//	3. start+period-1 之前的值保持为0
+//
+// 参数：
+//   - prices: 价格序列
+//   - period: 计算周期
+//   - start: 第一个有效数据的索引
+//
+// 返回值：
+//   - *TaEMA: 包含EMA计算结果的结构体指针，长度与输入一致
+//   - error: 计算过程中的错误，如数据不足等
+//
+// 示例：
+//
+//	// MACD的DIF在 longPeriod-1 之前为0，从该位置开始计算信号线
+//	dea, err := CalculateEMAFrom(dif, 9, 25)
+func CalculateEMAFrom(prices []float64, period, start int) (*TaEMA, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	if start < 0 {
+		start = 0
+	}
+	if len(prices)-start < period {
 		return nil, fmt.Errorf("计算数据不足")
 	}
 
@@ -59,22 +94,24 @@ func CalculateEMA(prices []float64, period int) (*TaEMA, error) {
 	slices := preallocateSlices(length, 1)
 	result := slices[0]
 
+	seed := start + period - 1
 	sum := 0.0
-	for i := 0; i < period; i++ {
+	for i := start; i <= seed; i++ {
 		sum += prices[i]
 	}
-	result[period-1] = sum / float64(period)
+	result[seed] = sum / float64(period)
 
 	multiplier := 2.0 / float64(period+1)
 	oneMinusMultiplier := 1.0 - multiplier
 
-	for i := period; i < length; i++ {
+	for i := seed + 1; i < length; i++ {
 		result[i] = prices[i]*multiplier + result[i-1]*oneMinusMultiplier
 	}
 
 	return &TaEMA{
-		Values: result,
-		Period: period,
+		Values:    result,
+		Period:    period,
+		ValidFrom: seed,
 	}, nil
 }
 
@@ -129,6 +166,13 @@ func (t *TaEMA) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaEMA) ContainsInvalid() bool {
+	return containsInvalid(t.Values)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
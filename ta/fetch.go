@@ -0,0 +1,77 @@
+package ta
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// KlineFetcher 单次K线请求函数
+// 说明：
+//
+//	由交易所客户端实现，返回从 startTime（毫秒）开始、按时间升序排列的一页K线
+//	每页数量由交易所上限决定，限频等待也应在该函数内完成
+type KlineFetcher func(ctx context.Context, startTime int64) (KlineDatas, error)
+
+// FetchKlinesRange 分页拉取指定时间范围内的历史K线
+// 说明：
+//
+//	循环调用 fetch，每次以上一页最后一根K线的开始时间+1毫秒作为下一页的起点，直到超过 end
+//	页与页边界重复的K线会被去重，结果只包含 [start, end) 范围内的K线
+//	fetch 返回空页或没有新数据时结束
+//	请求出错或 ctx 被取消时，返回已获取的部分数据和错误
+//
+// 参数：
+//   - ctx: 上下文，用于取消拉取
+//   - start: 开始时间（包含）
+//   - end: 结束时间（不包含）
+//   - fetch: 单页K线请求函数
+//
+// 返回值：
+//   - KlineDatas: 按时间升序排列的K线数据
+//   - error: 参数无效、请求失败或被取消时返回错误
+//
+// 示例：
+//
+//	klines, err := ta.FetchKlinesRange(ctx, start, end, func(ctx context.Context, startTime int64) (ta.KlineDatas, error) {
+//		return client.GetKlines(ctx, "BTCUSDT", "1m", startTime, 1000)
+//	})
+func FetchKlinesRange(ctx context.Context, start, end time.Time, fetch KlineFetcher) (KlineDatas, error) {
+	if fetch == nil {
+		return nil, fmt.Errorf("请求函数不能为空")
+	}
+	startMs, endMs := start.UnixMilli(), end.UnixMilli()
+	if startMs >= endMs {
+		return nil, fmt.Errorf("开始时间必须早于结束时间")
+	}
+
+	var result KlineDatas
+	lastTime := startMs - 1
+	for lastTime+1 < endMs {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		page, err := fetch(ctx, lastTime+1)
+		if err != nil {
+			return result, fmt.Errorf("拉取K线失败（起始时间%d）: %v", lastTime+1, err)
+		}
+
+		progressed := false
+		for _, kline := range page {
+			if kline == nil || kline.StartTime <= lastTime {
+				continue
+			}
+			if kline.StartTime >= endMs {
+				return result, nil
+			}
+			result = append(result, kline)
+			lastTime = kline.StartTime
+			progressed = true
+		}
+		if !progressed {
+			break
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,147 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// 斐波那契回撤与扩展比例
+var (
+	fibRetracementRatios = []float64{0, 0.236, 0.382, 0.5, 0.618, 0.786, 1}
+	fibExtensionRatios   = []float64{1.272, 1.618, 2}
+)
+
+// fibPivotPeriod AutoFib 确认摆动高低点所需的左右K线数量
+const fibPivotPeriod = 3
+
+// TaFib 表示斐波那契回撤/扩展位的计算结果
+// 说明：
+//
+//	Levels 以比例字符串为键，如 "0.618"、"1.618"
+//	上涨波段（先低后高）：
+//	- 回撤位 = 高点 - 波幅 * 比例，"0" 为高点，"1" 为低点
+//	- 扩展位 = 低点 + 波幅 * 比例，位于高点上方
+//	下跌波段（先高后低）：
+//	- 回撤位 = 低点 + 波幅 * 比例，"0" 为低点，"1" 为高点
+//	- 扩展位 = 高点 - 波幅 * 比例，位于低点下方
+type TaFib struct {
+	High       float64            `json:"high"`       // 波段最高价
+	Low        float64            `json:"low"`        // 波段最低价
+	UpSwing    bool               `json:"up_swing"`   // 是否为上涨波段
+	HighIndex  int                `json:"high_index"` // 高点所在K线索引（AutoFib计算时有效，否则为-1）
+	LowIndex   int                `json:"low_index"`  // 低点所在K线索引（AutoFib计算时有效，否则为-1）
+	Levels     map[string]float64 `json:"levels"`     // 各比例对应的价格
+	Extensions bool               `json:"extensions"` // 是否包含扩展位
+}
+
+// FibLevels 根据波段高低点计算斐波那契回撤位和扩展位
+// 说明：
+//
+//	回撤比例：0.236、0.382、0.5、0.618、0.786（另含两端的 0 和 1）
+//	扩展比例：1.272、1.618、2
+//
+// 参数：
+//   - high: 波段最高价
+//   - low: 波段最低价
+//   - upSwing: 是否为上涨波段（先出现低点再出现高点）
+//   - extensions: 是否计算扩展位
+//
+// 返回值：
+//   - *TaFib: 包含斐波那契价位的结构体指针
+//   - error: 高点不大于低点时返回错误
+//
+// 示例：
+//
+//	fib, err := FibLevels(70000, 60000, true, true)
+//	support := fib.Levels["0.618"] // 63820
+func FibLevels(high, low float64, upSwing, extensions bool) (*TaFib, error) {
+	if !(high > low) {
+		return nil, fmt.Errorf("高点(%v)必须大于低点(%v)", high, low)
+	}
+
+	diff := high - low
+	levels := make(map[string]float64, len(fibRetracementRatios)+len(fibExtensionRatios))
+	for _, ratio := range fibRetracementRatios {
+		if upSwing {
+			levels[fibKey(ratio)] = high - diff*ratio
+		} else {
+			levels[fibKey(ratio)] = low + diff*ratio
+		}
+	}
+	if extensions {
+		for _, ratio := range fibExtensionRatios {
+			if upSwing {
+				levels[fibKey(ratio)] = low + diff*ratio
+			} else {
+				levels[fibKey(ratio)] = high - diff*ratio
+			}
+		}
+	}
+
+	return &TaFib{
+		High:       high,
+		Low:        low,
+		UpSwing:    upSwing,
+		HighIndex:  -1,
+		LowIndex:   -1,
+		Levels:     levels,
+		Extensions: extensions,
+	}, nil
+}
+
+// fibKey 生成比例对应的键
+func fibKey(ratio float64) string {
+	return strconv.FormatFloat(ratio, 'f', -1, 64)
+}
+
+// AutoFib 自动识别最近的摆动波段并计算斐波那契价位
+// 说明：
+//
+//	在最近lookback根K线中，使用轴点规则（左右各3根K线）找出最高的高点轴点与最低的低点轴点，
+//	高点出现在低点之后视为上涨波段，反之为下跌波段，结果包含扩展位
+//	轴点需要右侧K线确认，最近3根K线不会被识别为摆动点
+//
+// 参数：
+//   - lookback: 向前查找的K线数量，通常为50-200
+//
+// 返回值：
+//   - *TaFib: 包含斐波那契价位的结构体指针，HighIndex/LowIndex 为摆动点的K线索引
+//   - error: 数据不足或未找到摆动点时返回错误
+//
+// 示例：
+//
+//	fib, err := klines.AutoFib(100)
+func (k KlineDatas) AutoFib(lookback int) (*TaFib, error) {
+	length := len(k)
+	if lookback <= fibPivotPeriod*2 || length <= fibPivotPeriod*2 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	start := length - lookback
+	if start < 0 {
+		start = 0
+	}
+
+	highIndex, lowIndex := -1, -1
+	high, low := math.Inf(-1), math.Inf(1)
+	for i := start; i < length; i++ {
+		if h := FindPivotHighPoint(k, i, fibPivotPeriod); !math.IsNaN(h) && h > high {
+			high, highIndex = h, i
+		}
+		if l := FindPivotLowPoint(k, i, fibPivotPeriod); !math.IsNaN(l) && l < low {
+			low, lowIndex = l, i
+		}
+	}
+	if highIndex == -1 || lowIndex == -1 {
+		return nil, fmt.Errorf("最近%d根K线内未找到摆动高低点", lookback)
+	}
+
+	fib, err := FibLevels(high, low, highIndex > lowIndex, true)
+	if err != nil {
+		return nil, err
+	}
+	fib.HighIndex = highIndex
+	fib.LowIndex = lowIndex
+	return fib, nil
+}
@@ -0,0 +1,264 @@
+package ta
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Interval K线周期
+// 说明：
+//
+//	统一使用币安风格的写法表示K线周期，通过 ToExchange 转换为各交易所接口要求的格式
+type Interval string
+
+// 支持的K线周期
+const (
+	Interval1m  Interval = "1m"
+	Interval3m  Interval = "3m"
+	Interval5m  Interval = "5m"
+	Interval15m Interval = "15m"
+	Interval30m Interval = "30m"
+	Interval1h  Interval = "1h"
+	Interval2h  Interval = "2h"
+	Interval4h  Interval = "4h"
+	Interval6h  Interval = "6h"
+	Interval8h  Interval = "8h"
+	Interval12h Interval = "12h"
+	Interval1d  Interval = "1d"
+	Interval3d  Interval = "3d"
+	Interval1w  Interval = "1w"
+	Interval1M  Interval = "1M"
+)
+
+// intervalDurations 各周期对应的时长，月线按30天计算
+var intervalDurations = map[Interval]time.Duration{
+	Interval1m:  time.Minute,
+	Interval3m:  3 * time.Minute,
+	Interval5m:  5 * time.Minute,
+	Interval15m: 15 * time.Minute,
+	Interval30m: 30 * time.Minute,
+	Interval1h:  time.Hour,
+	Interval2h:  2 * time.Hour,
+	Interval4h:  4 * time.Hour,
+	Interval6h:  6 * time.Hour,
+	Interval8h:  8 * time.Hour,
+	Interval12h: 12 * time.Hour,
+	Interval1d:  24 * time.Hour,
+	Interval3d:  3 * 24 * time.Hour,
+	Interval1w:  7 * 24 * time.Hour,
+	Interval1M:  30 * 24 * time.Hour,
+}
+
+// intervalFormats 各交易所的周期写法，缺少的周期表示该交易所不支持
+var intervalFormats = map[string]map[Interval]string{
+	"binance": {
+		Interval1m: "1m", Interval3m: "3m", Interval5m: "5m", Interval15m: "15m", Interval30m: "30m",
+		Interval1h: "1h", Interval2h: "2h", Interval4h: "4h", Interval6h: "6h", Interval8h: "8h", Interval12h: "12h",
+		Interval1d: "1d", Interval3d: "3d", Interval1w: "1w", Interval1M: "1M",
+	},
+	"gate": {
+		Interval1m: "1m", Interval5m: "5m", Interval15m: "15m", Interval30m: "30m",
+		Interval1h: "1h", Interval4h: "4h", Interval8h: "8h",
+		Interval1d: "1d", Interval1w: "7d", Interval1M: "30d",
+	},
+	"bybit": {
+		Interval1m: "1", Interval3m: "3", Interval5m: "5", Interval15m: "15", Interval30m: "30",
+		Interval1h: "60", Interval2h: "120", Interval4h: "240", Interval6h: "360", Interval12h: "720",
+		Interval1d: "D", Interval1w: "W", Interval1M: "M",
+	},
+	"bitget": {
+		Interval1m: "1m", Interval3m: "3m", Interval5m: "5m", Interval15m: "15m", Interval30m: "30m",
+		Interval1h: "1H", Interval4h: "4H", Interval6h: "6H", Interval12h: "12H",
+		Interval1d: "1D", Interval3d: "3D", Interval1w: "1W", Interval1M: "1M",
+	},
+	"backpack": {
+		Interval1m: "1m", Interval3m: "3m", Interval5m: "5m", Interval15m: "15m", Interval30m: "30m",
+		Interval1h: "1h", Interval2h: "2h", Interval4h: "4h", Interval6h: "6h", Interval8h: "8h", Interval12h: "12h",
+		Interval1d: "1d", Interval3d: "3d", Interval1w: "1w", Interval1M: "1month",
+	},
+}
+
+// ParseInterval 解析并校验K线周期
+// 参数：
+//   - s: 币安风格的周期写法，如"1m"、"4h"、"1d"
+//
+// 返回值：
+//   - Interval: 解析后的周期
+//   - error: 周期不受支持时返回错误
+func ParseInterval(s string) (Interval, error) {
+	interval := Interval(s)
+	if !interval.Valid() {
+		return "", fmt.Errorf("不支持的K线周期: %s", s)
+	}
+	return interval, nil
+}
+
+// Valid 判断周期是否受支持
+func (i Interval) Valid() bool {
+	_, ok := intervalDurations[i]
+	return ok
+}
+
+// Duration 获取周期对应的时长
+// 说明：
+//
+//	月线按30天计算，仅用于估算K线数量或时间范围，不适合精确的日历计算
+//
+// 返回值：
+//   - time.Duration: 周期时长，不支持的周期返回0
+func (i Interval) Duration() time.Duration {
+	return intervalDurations[i]
+}
+
+// ToExchange 转换为指定交易所接口要求的周期写法
+// 说明：
+//
+//	支持的交易所："binance"、"gate"、"bybit"、"bitget"、"backpack"
+//	例如 Interval1h 在 bybit 为"60"，在 bitget 为"1H"；Interval1w 在 gate 为"7d"
+//
+// 参数：
+//   - exchange: 交易所名称
+//
+// 返回值：
+//   - string: 交易所的周期写法
+//   - error: 交易所未知或该交易所不支持此周期时返回错误
+//
+// 示例：
+//
+//	interval, err := ta.Interval4h.ToExchange("bybit") // "240"
+func (i Interval) ToExchange(exchange string) (string, error) {
+	formats, ok := intervalFormats[exchange]
+	if !ok {
+		return "", fmt.Errorf("未知的交易所: %s", exchange)
+	}
+	format, ok := formats[i]
+	if !ok {
+		return "", fmt.Errorf("%s 不支持K线周期: %s", exchange, i)
+	}
+	return format, nil
+}
+
+// String 返回周期的字符串写法
+func (i Interval) String() string {
+	return string(i)
+}
+
+// NextClose 计算 now 之后下一个K线收盘时刻
+// 说明：
+//
+//	收盘时刻按UTC对齐到周期边界，如15m周期为每小时的:00、:15、:30、:45，1d周期为UTC 0点
+//	以Unix纪元为起点对齐，周线与交易所一致按周一UTC 0点对齐
+//	now 恰好处于边界上时返回下一个边界；interval 小于等于0时返回 now
+//	月线等不固定长度的周期无法按时长对齐，请勿使用
+//
+// 参数：
+//   - interval: K线周期，如 ta.Interval15m.Duration()
+//   - now: 当前时间
+//
+// 返回值：
+//   - time.Time: 下一个收盘时刻（UTC）
+//
+// 示例：
+//
+//	next := ta.NextClose(15*time.Minute, time.Now())
+func NextClose(interval time.Duration, now time.Time) time.Time {
+	if interval <= 0 {
+		return now
+	}
+	start := alignStart(now.UnixNano(), int64(interval), int64(intervalOffset(interval)))
+	return time.Unix(0, start+int64(interval)).UTC()
+}
+
+// intervalOffset 返回周期边界相对Unix纪元的偏移
+// 1970-01-01 为周四，周线偏移4天与交易所一致对齐到周一，其他周期不偏移
+func intervalOffset(interval time.Duration) time.Duration {
+	if interval == 7*24*time.Hour {
+		return 4 * 24 * time.Hour
+	}
+	return 0
+}
+
+// alignStart 将时间 t 向下对齐到所在周期的起点，t、interval、offset 单位需一致
+// 所有按周期对齐的函数（NextClose、Resample）共用该规则，保证边界一致
+func alignStart(t, interval, offset int64) int64 {
+	rem := (t - offset) % interval
+	if rem < 0 {
+		rem += interval
+	}
+	return t - rem
+}
+
+// NextCloseTimer 创建在下一个K线收盘时刻触发的定时器
+// 说明：
+//
+//	对齐规则见 NextClose，定时器的触发精度取决于系统调度，通常有毫秒级延迟
+//	收盘后交易所推送最终K线也有延迟，拉取数据前可视需要再等待片刻
+//
+// 参数：
+//   - interval: K线周期
+//
+// 返回值：
+//   - *time.Timer: 在下一个收盘时刻触发的定时器
+//
+// 示例：
+//
+//	timer := ta.NextCloseTimer(15 * time.Minute)
+//	<-timer.C
+func NextCloseTimer(interval time.Duration) *time.Timer {
+	return time.NewTimer(time.Until(NextClose(interval, time.Now())))
+}
+
+// CloseTicker 在每个K线收盘时刻发送一次收盘时间
+// 说明：
+//
+//	对齐规则见 NextClose，发送的值为本次的收盘时刻而非实际触发时间
+//	与 time.Ticker 相同，接收方处理过慢时会丢弃来不及接收的收盘时刻
+//	ctx 取消后停止并关闭通道；interval 小于等于0时直接返回已关闭的通道
+//
+// 参数：
+//   - ctx: 上下文，用于停止
+//   - interval: K线周期
+//
+// 返回值：
+//   - <-chan time.Time: 收盘时刻通道
+//
+// 示例：
+//
+//	for closeTime := range ta.CloseTicker(ctx, 15*time.Minute) {
+//		fmt.Println("K线收盘", closeTime)
+//	}
+func CloseTicker(ctx context.Context, interval time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	if interval <= 0 {
+		close(ch)
+		return ch
+	}
+
+	go func() {
+		defer close(ch)
+		next := NextClose(interval, time.Now())
+		timer := time.NewTimer(time.Until(next))
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+			select {
+			case ch <- next:
+			default:
+			}
+			next = NextClose(interval, next)
+			timer.Reset(time.Until(next))
+		}
+	}()
+	return ch
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
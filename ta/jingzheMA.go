@@ -24,6 +24,7 @@ type TaJingZheMA struct {
 	Cond5Values []float64 `json:"cond5_values"` // 条件5结果序列
 	Period1     int       `json:"period1"`      // 主要周期
 	Period2     int       `json:"period2"`      // OBV周期
+	ValidFrom   int       `json:"valid_from"`   // 首个有效值的索引（最长EMA完成预热的位置）
 }
 
 // CalculateJingZheMA 计算惊蛰均线指标
@@ -178,6 +179,7 @@ func CalculateJingZheMA(prices, volumes []float64, period1, period2 int) (*TaJin
 		Cond5Values: cond5,
 		Period1:     period1,
 		Period2:     period2,
+		ValidFrom:   period1*3 - 1,
 	}, nil
 }
 
@@ -243,6 +245,13 @@ func (t *TaJingZheMA) Value() (float64, float64, float64, float64, float64) {
 	return t.Cond1Values[lastIndex], t.Cond2Values[lastIndex], t.Cond3Values[lastIndex], t.Cond4Values[lastIndex], t.Cond5Values[lastIndex]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaJingZheMA) ContainsInvalid() bool {
+	return containsInvalid(t.Cond1Values, t.Cond2Values, t.Cond3Values, t.Cond4Values, t.Cond5Values)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
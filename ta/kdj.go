@@ -17,9 +17,10 @@ import (
 //	- 20以下为超卖区
 //	- 常用于预测价格走势反转
 type TaKDJ struct {
-	K []float64 `json:"k"` // K值序列（快速线）
-	D []float64 `json:"d"` // D值序列（慢速线）
-	J []float64 `json:"j"` // J值序列（方向线）
+	K         []float64 `json:"k"`          // K值序列（快速线）
+	D         []float64 `json:"d"`          // D值序列（慢速线）
+	J         []float64 `json:"j"`          // J值序列（方向线）
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
 }
 
 // CalculateKDJ 计算随机指标
@@ -77,11 +78,7 @@ func CalculateKDJ(high, low, close []float64, rsvPeriod, kPeriod, dPeriod int) (
 			}
 		}
 
-		if highestHigh != lowestLow {
-			rsv[i] = (close[i] - lowestLow) / (highestHigh - lowestLow) * 100
-		} else {
-			rsv[i] = 50
-		}
+		rsv[i] = safeDivide(close[i]-lowestLow, highestHigh-lowestLow, 0.5) * 100
 	}
 
 	k[rsvPeriod-1] = rsv[rsvPeriod-1]
@@ -98,9 +95,10 @@ func CalculateKDJ(high, low, close []float64, rsvPeriod, kPeriod, dPeriod int) (
 	}
 
 	return &TaKDJ{
-		K: k,
-		D: d,
-		J: j,
+		K:         k,
+		D:         d,
+		J:         j,
+		ValidFrom: rsvPeriod - 1,
 	}, nil
 }
 
@@ -172,6 +170,13 @@ func (t *TaKDJ) Value() (k, d, j float64) {
 	return t.K[lastIndex], t.D[lastIndex], t.J[lastIndex]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaKDJ) ContainsInvalid() bool {
+	return containsInvalid(t.K, t.D, t.J)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
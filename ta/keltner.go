@@ -0,0 +1,154 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// TaKeltner 表示肯特纳通道(Keltner Channels)的计算结果
+// 说明：
+//
+//	肯特纳通道是基于ATR的价格通道，包含三条轨道线：
+//	1. 中轨：典型价格 (最高价+最低价+收盘价)/3 的EMA
+//	2. 上轨：中轨 + multiplier * ATR
+//	3. 下轨：中轨 - multiplier * ATR
+//	特点：
+//	- 轨道宽度随真实波幅变化，比布林带更平滑
+//	- 价格持续运行在上轨之外通常表示强势趋势
+//	- 常与布林带配合判断波动收缩（挤压）
+type TaKeltner struct {
+	Upper      []float64 `json:"upper"`      // 上轨线序列
+	Mid        []float64 `json:"mid"`        // 中轨线序列（典型价格EMA）
+	Lower      []float64 `json:"lower"`      // 下轨线序列
+	EmaPeriod  int       `json:"ema_period"` // 中轨EMA周期
+	AtrPeriod  int       `json:"atr_period"` // ATR计算周期
+	Multiplier float64   `json:"multiplier"` // ATR乘数
+	ValidFrom  int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
+}
+
+// CalculateKeltner 计算肯特纳通道指标
+// 说明：
+//
+//	计算步骤：
+//	1. 计算典型价格 TP = (最高价 + 最低价 + 收盘价) / 3
+//	2. 计算中轨 = EMA(TP, emaPeriod)
+//	3. 计算ATR(atrPeriod)
+//	4. 上轨 = 中轨 + multiplier * ATR，下轨 = 中轨 - multiplier * ATR
+//	中轨与ATR都完成预热后轨道才有效，之前的值为0
+//
+// 参数：
+//   - klineData: K线数据
+//   - emaPeriod: 中轨EMA周期，通常为20
+//   - atrPeriod: ATR计算周期，通常为10-20
+//   - multiplier: ATR乘数，通常为1.5-2
+//
+// 返回值：
+//   - *TaKeltner: 包含肯特纳通道计算结果的结构体指针
+//   - error: 计算过程中的错误，如数据不足等
+//
+// 示例：
+//
+//	kc, err := CalculateKeltner(klineData, 20, 10, 2.0)
+func CalculateKeltner(klineData KlineDatas, emaPeriod, atrPeriod int, multiplier float64) (*TaKeltner, error) {
+	length := len(klineData)
+	if emaPeriod <= 0 || length < emaPeriod {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	typical := make([]float64, length)
+	for i, kline := range klineData {
+		typical[i] = (kline.High + kline.Low + kline.Close) / 3
+	}
+
+	ema, err := CalculateEMA(typical, emaPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	atr, err := CalculateATR(klineData, atrPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	slices := preallocateSlices(length, 3)
+	upper, mid, lower := slices[0], slices[1], slices[2]
+
+	validFrom := max(ema.ValidFrom, atr.ValidFrom)
+	for i := validFrom; i < length; i++ {
+		band := multiplier * atr.Values[i]
+		mid[i] = ema.Values[i]
+		upper[i] = mid[i] + band
+		lower[i] = mid[i] - band
+	}
+
+	return &TaKeltner{
+		Upper:      upper,
+		Mid:        mid,
+		Lower:      lower,
+		EmaPeriod:  emaPeriod,
+		AtrPeriod:  atrPeriod,
+		Multiplier: multiplier,
+		ValidFrom:  validFrom,
+	}, nil
+}
+
+// Keltner 为K线数据计算肯特纳通道指标
+// 参数：
+//   - emaPeriod: 中轨EMA周期
+//   - atrPeriod: ATR计算周期
+//   - multiplier: ATR乘数
+//
+// 返回值：
+//   - *TaKeltner: 包含肯特纳通道计算结果的结构体指针
+//   - error: 计算过程中的错误
+func (k *KlineDatas) Keltner(emaPeriod, atrPeriod int, multiplier float64) (*TaKeltner, error) {
+	return CalculateKeltner(*k, emaPeriod, atrPeriod, multiplier)
+}
+
+// Keltner_ 获取最新的肯特纳通道值
+// 参数：
+//   - emaPeriod: 中轨EMA周期
+//   - atrPeriod: ATR计算周期
+//   - multiplier: ATR乘数
+//
+// 返回值：
+//   - float64: 最新的上轨值
+//   - float64: 最新的中轨值
+//   - float64: 最新的下轨值
+func (k *KlineDatas) Keltner_(emaPeriod, atrPeriod int, multiplier float64) (float64, float64, float64) {
+	kc, err := k.Keltner(emaPeriod, atrPeriod, multiplier)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return kc.Value()
+}
+
+// Value 获取最新的肯特纳通道值
+// 说明：
+//
+//	返回最新的上中下轨值
+//	使用建议：
+//	- 收盘价站上上轨表示多头强势
+//	- 收盘价跌破下轨表示空头强势
+//	- 布林带收缩进肯特纳通道内表示波动率挤压
+//
+// 返回值：
+//   - upper: 上轨值
+//   - mid: 中轨值
+//   - lower: 下轨值
+func (t *TaKeltner) Value() (upper, mid, lower float64) {
+	lastIndex := len(t.Upper) - 1
+	return t.Upper[lastIndex], t.Mid[lastIndex], t.Lower[lastIndex]
+}
+
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaKeltner) ContainsInvalid() bool {
+	return containsInvalid(t.Upper, t.Mid, t.Lower)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
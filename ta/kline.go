@@ -1,6 +1,10 @@
 package ta
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"time"
+)
 
 // Keep 保留最后N根K线并返回新的数据集
 // 说明：
@@ -130,3 +134,340 @@ func (kline *KlineData) Value(source string) float64 {
 		return -1
 	}
 }
+
+// Walk 按时间顺序逐根回放K线，用于回测
+// 说明：
+//
+//	对每个索引 i >= minBars 调用一次 fn，window 为 k[:i+1]，即截至第i根K线（含）的全部数据
+//	window 与原数据共享底层数组，回放过程不会复制K线
+//	window 的容量被限制为其长度，对 window 执行 append 不会覆盖原数据中后续的K线
+//	但修改 window 中K线的字段会直接影响原数据，回调中请只读使用
+//	minBars 小于0时按0处理，大于等于数据长度时不会调用 fn
+//
+// 参数：
+//   - minBars: 开始回调的最小索引，通常取指标所需的预热长度
+//   - fn: 回调函数，window 为当前可见的K线数据，i 为当前K线的索引
+//
+// 示例：
+//
+//	klines.Walk(50, func(window ta.KlineDatas, i int) {
+//		rsi := window.RSI_(14, "close")
+//		fmt.Println(i, rsi)
+//	})
+func (k KlineDatas) Walk(minBars int, fn func(window KlineDatas, i int)) {
+	if fn == nil {
+		return
+	}
+	if minBars < 0 {
+		minBars = 0
+	}
+	for i := minBars; i < len(k); i++ {
+		fn(k[:i+1:i+1], i)
+	}
+}
+
+// Closed 返回去掉末尾未收盘K线后的数据
+// 说明：
+//
+//	NewKlineDatas 设置 KeepUnclosed 时最后一根K线会被标记为 Unclosed
+//	计算只应基于已收盘K线的指标时，可先调用 Closed 排除该K线
+//	结果与原数据共享底层数组，不会复制K线
+//
+// 返回值：
+//   - KlineDatas: 只包含已收盘K线的数据集合
+//
+// 示例：
+//
+//	closed := klines.Closed()
+//	rsi := closed.RSI_(14, "close")
+func (k KlineDatas) Closed() KlineDatas {
+	n := len(k)
+	for n > 0 && k[n-1].Unclosed {
+		n--
+	}
+	return k[:n:n]
+}
+
+// HeikinAshi 将K线转换为平均K线（Heikin-Ashi）
+// 说明：
+//
+//	返回新的K线数据集，不修改原数据：
+//	- HA收盘价 = (开盘价 + 最高价 + 最低价 + 收盘价) / 4
+//	- HA开盘价 = (前一根HA开盘价 + 前一根HA收盘价) / 2，第一根取 (开盘价 + 收盘价) / 2
+//	- HA最高价 = max(最高价, HA开盘价, HA收盘价)
+//	- HA最低价 = min(最低价, HA开盘价, HA收盘价)
+//	StartTime、Volume 与 Unclosed 保持不变，结果可直接用于 SuperTrend、ADX 等指标计算
+//
+// 返回值：
+//   - KlineDatas: 转换后的K线数据集合，原数据为空时返回nil
+//
+// 示例：
+//
+//	ha := klines.HeikinAshi()
+//	st, err := ha.SuperTrend(10, 3.0)
+func (k KlineDatas) HeikinAshi() KlineDatas {
+	if len(k) == 0 {
+		return nil
+	}
+
+	ha := make(KlineDatas, len(k))
+	for i, kline := range k {
+		haClose := (kline.Open + kline.High + kline.Low + kline.Close) / 4
+		var haOpen float64
+		if i == 0 {
+			haOpen = (kline.Open + kline.Close) / 2
+		} else {
+			haOpen = (ha[i-1].Open + ha[i-1].Close) / 2
+		}
+		ha[i] = &KlineData{
+			StartTime: kline.StartTime,
+			Open:      haOpen,
+			High:      math.Max(kline.High, math.Max(haOpen, haClose)),
+			Low:       math.Min(kline.Low, math.Min(haOpen, haClose)),
+			Close:     haClose,
+			Volume:    kline.Volume,
+			Unclosed:  kline.Unclosed,
+		}
+	}
+	return ha
+}
+
+// Resample 将低周期K线合成为高周期K线
+// 说明：
+//
+//	按 StartTime 对齐到 targetInterval 的时间桶分组聚合，对齐规则与 NextClose 相同：
+//	以Unix纪元为起点按UTC对齐，周线按周一UTC 0点对齐
+//	开盘价取桶内第一根，最高价取最大值，最低价取最小值，收盘价取最后一根，成交量求和
+//	源周期取相邻K线 StartTime 差值的最小值，targetInterval 必须是源周期的整数倍
+//	首尾两个时间桶可能不完整：首桶的第一根K线不在桶起点，或尾桶的最后一根K线未到桶终点
+//	默认丢弃不完整的首尾桶，keepPartial 为 true 时保留；中间因缺K线而不完整的桶始终保留
+//	桶内任一K线为 Unclosed 时，合成的K线同样标记为 Unclosed
+//	返回新的数据集，不修改原数据
+//
+// 参数：
+//   - targetInterval: 目标周期，如 15*time.Minute
+//   - keepPartial: 可选，是否保留不完整的首尾时间桶，默认false
+//
+// 返回值：
+//   - KlineDatas: 合成后的K线数据集合
+//   - error: 数据不足、时间未按升序排列或周期不能整除时返回错误
+//
+// 示例：
+//
+//	k15m, err := k1m.Resample(15 * time.Minute)
+func (k KlineDatas) Resample(targetInterval time.Duration, keepPartial ...bool) (KlineDatas, error) {
+	if len(k) < 2 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+	target := targetInterval.Milliseconds()
+	if target <= 0 {
+		return nil, fmt.Errorf("目标周期必须大于0")
+	}
+
+	var source int64
+	for i := 1; i < len(k); i++ {
+		diff := k[i].StartTime - k[i-1].StartTime
+		if diff <= 0 {
+			return nil, fmt.Errorf("K线时间未按升序排列，索引 %d", i)
+		}
+		if source == 0 || diff < source {
+			source = diff
+		}
+	}
+	if target%source != 0 {
+		return nil, fmt.Errorf("目标周期(%s)不是源周期(%s)的整数倍", targetInterval, time.Duration(source)*time.Millisecond)
+	}
+
+	offset := intervalOffset(targetInterval).Milliseconds()
+	result := make(KlineDatas, 0, len(k)*int(source)/int(target)+1)
+	var current *KlineData
+	for _, kline := range k {
+		bucket := alignStart(kline.StartTime, target, offset)
+		if current == nil || bucket != current.StartTime {
+			current = &KlineData{
+				StartTime: bucket,
+				Open:      kline.Open,
+				High:      kline.High,
+				Low:       kline.Low,
+				Close:     kline.Close,
+				Volume:    kline.Volume,
+				Unclosed:  kline.Unclosed,
+			}
+			result = append(result, current)
+			continue
+		}
+		current.High = math.Max(current.High, kline.High)
+		current.Low = math.Min(current.Low, kline.Low)
+		current.Close = kline.Close
+		current.Volume += kline.Volume
+		current.Unclosed = current.Unclosed || kline.Unclosed
+	}
+
+	if len(keepPartial) > 0 && keepPartial[0] {
+		return result, nil
+	}
+	if k[0].StartTime != result[0].StartTime {
+		result = result[1:]
+	}
+	if last := k[len(k)-1]; len(result) > 0 && last.StartTime+source < result[len(result)-1].StartTime+target {
+		result = result[:len(result)-1]
+	}
+	return result, nil
+}
+
+// Gap 表示K线序列中的一段缺口
+type Gap struct {
+	Index   int   `json:"index"`   // 缺口后第一根K线的索引
+	From    int64 `json:"from"`    // 第一根缺失K线的开始时间（毫秒）
+	To      int64 `json:"to"`      // 最后一根缺失K线的开始时间（毫秒）
+	Missing int   `json:"missing"` // 缺失的K线数量
+}
+
+// GapFillMethod 缺口填充方式
+type GapFillMethod string
+
+const (
+	// GapFillForward 以前一根K线的收盘价作为开高低收，成交量为0
+	GapFillForward GapFillMethod = "forward"
+	// GapFillLinear 收盘价在缺口前一根的收盘价与缺口后一根的开盘价之间线性插值，开盘价取前一根的收盘价，成交量为0
+	GapFillLinear GapFillMethod = "linear"
+)
+
+// FindGaps 查找K线序列中的缺口
+// 说明：
+//
+//	相邻两根K线的 StartTime 差值超过一个周期即视为缺口
+//	差值不是周期整数倍时，按向下取整计算缺失数量
+//
+// 参数：
+//   - interval: K线周期
+//
+// 返回值：
+//   - []Gap: 缺口列表，按时间升序排列，无缺口或周期无效时返回nil
+//
+// 示例：
+//
+//	for _, gap := range klines.FindGaps(time.Minute) {
+//		fmt.Println(gap.Index, gap.Missing)
+//	}
+func (k KlineDatas) FindGaps(interval time.Duration) []Gap {
+	step := interval.Milliseconds()
+	if step <= 0 {
+		return nil
+	}
+
+	var gaps []Gap
+	for i := 1; i < len(k); i++ {
+		diff := k[i].StartTime - k[i-1].StartTime
+		if diff <= step {
+			continue
+		}
+		missing := (diff - 1) / step
+		gaps = append(gaps, Gap{
+			Index:   i,
+			From:    k[i-1].StartTime + step,
+			To:      k[i-1].StartTime + missing*step,
+			Missing: int(missing),
+		})
+	}
+	return gaps
+}
+
+// FillGaps 在缺口处插入合成K线
+// 说明：
+//
+//	根据 FindGaps 找到的缺口，按 method 生成缺失的K线并返回新数据集，不修改原数据
+//	合成K线的成交量均为0，可通过 Volume == 0 识别；原有K线直接复用指针，不会复制
+//
+// 参数：
+//   - interval: K线周期
+//   - method: 填充方式，GapFillForward 或 GapFillLinear
+//
+// 返回值：
+//   - KlineDatas: 填充后的K线数据集合
+//   - error: 周期无效或填充方式不支持时返回错误
+//
+// 示例：
+//
+//	filled, err := klines.FillGaps(time.Minute, ta.GapFillForward)
+func (k KlineDatas) FillGaps(interval time.Duration, method GapFillMethod) (KlineDatas, error) {
+	step := interval.Milliseconds()
+	if step <= 0 {
+		return nil, fmt.Errorf("K线周期必须大于0")
+	}
+	if method != GapFillForward && method != GapFillLinear {
+		return nil, fmt.Errorf("不支持的填充方式: %s", method)
+	}
+
+	gaps := k.FindGaps(interval)
+	total := len(k)
+	for _, gap := range gaps {
+		total += gap.Missing
+	}
+
+	result := make(KlineDatas, 0, total)
+	next := 0
+	for i, kline := range k {
+		if next < len(gaps) && gaps[next].Index == i {
+			prev, gap := k[i-1], gaps[next]
+			for j := 1; j <= gap.Missing; j++ {
+				price := prev.Close
+				if method == GapFillLinear {
+					price = prev.Close + (kline.Open-prev.Close)*float64(j)/float64(gap.Missing+1)
+				}
+				open := result[len(result)-1].Close
+				result = append(result, &KlineData{
+					StartTime: prev.StartTime + int64(j)*step,
+					Open:      open,
+					High:      math.Max(open, price),
+					Low:       math.Min(open, price),
+					Close:     price,
+				})
+			}
+			next++
+		}
+		result = append(result, kline)
+	}
+	return result, nil
+}
+
+// DetectInterval 根据 StartTime 自动识别K线周期
+// 说明：
+//
+//	取相邻K线 StartTime 差值的众数作为周期，少量缺口不影响结果
+//	众数出现次数不足全部差值的一半时视为数据过于不规则并返回错误
+//	差值出现次数相同时取较小的差值
+//
+// 返回值：
+//   - time.Duration: 识别出的K线周期
+//   - error: 数据不足、时间未按升序排列或数据过于不规则时返回错误
+//
+// 示例：
+//
+//	interval, err := klines.DetectInterval()
+//	gaps := klines.FindGaps(interval)
+func (k KlineDatas) DetectInterval() (time.Duration, error) {
+	if len(k) < 3 {
+		return 0, fmt.Errorf("计算数据不足")
+	}
+
+	counts := make(map[int64]int)
+	for i := 1; i < len(k); i++ {
+		diff := k[i].StartTime - k[i-1].StartTime
+		if diff <= 0 {
+			return 0, fmt.Errorf("K线时间未按升序排列，索引 %d", i)
+		}
+		counts[diff]++
+	}
+
+	var mode int64
+	for diff, count := range counts {
+		if count > counts[mode] || count == counts[mode] && diff < mode {
+			mode = diff
+		}
+	}
+	if total := len(k) - 1; counts[mode]*2 < total {
+		return 0, fmt.Errorf("K线时间间隔不规则，出现最多的间隔仅占 %d/%d", counts[mode], total)
+	}
+	return time.Duration(mode) * time.Millisecond, nil
+}
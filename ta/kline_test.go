@@ -0,0 +1,95 @@
+package ta
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResampleWeeklyAlignsToMonday(t *testing.T) {
+	const day = int64(24 * time.Hour / time.Millisecond)
+	// 2024-01-01 为周一，构造两周完整日线
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	var daily KlineDatas
+	for i := int64(0); i < 14; i++ {
+		daily = append(daily, &KlineData{
+			StartTime: monday + i*day,
+			Open:      float64(i + 1),
+			High:      float64(i + 2),
+			Low:       float64(i),
+			Close:     float64(i + 1),
+			Volume:    1,
+		})
+	}
+
+	weekly, err := daily.Resample(7 * 24 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(weekly) != 2 {
+		t.Fatalf("got %d weekly bars, want 2", len(weekly))
+	}
+	for i, bar := range weekly {
+		start := time.UnixMilli(bar.StartTime).UTC()
+		if start.Weekday() != time.Monday {
+			t.Errorf("bar %d starts on %s, want Monday", i, start.Weekday())
+		}
+		if bar.Volume != 7 {
+			t.Errorf("bar %d volume = %v, want 7", i, bar.Volume)
+		}
+	}
+	if weekly[0].Open != 1 || weekly[0].Close != 7 || weekly[0].High != 8 || weekly[0].Low != 0 {
+		t.Errorf("first bar OHLC = %v/%v/%v/%v", weekly[0].Open, weekly[0].High, weekly[0].Low, weekly[0].Close)
+	}
+
+	// 与 NextClose 的边界保持一致
+	mid := time.UnixMilli(monday + 3*day + 12*int64(time.Hour/time.Millisecond))
+	if got := NextClose(7*24*time.Hour, mid).UnixMilli(); got != weekly[1].StartTime {
+		t.Errorf("NextClose = %d, want %d (start of the next resampled week)", got, weekly[1].StartTime)
+	}
+}
+
+func TestHeikinAshi(t *testing.T) {
+	input := KlineDatas{
+		{StartTime: 1000, Open: 10, High: 12, Low: 9, Close: 11, Volume: 5},
+		{StartTime: 2000, Open: 11, High: 14, Low: 10, Close: 13, Volume: 6},
+		{StartTime: 3000, Open: 13, High: 13.5, Low: 8, Close: 9, Volume: 7, Unclosed: true},
+	}
+	snapshot := make([]KlineData, len(input))
+	for i, kline := range input {
+		snapshot[i] = *kline
+	}
+
+	ha := input.HeikinAshi()
+
+	// 第一根以 (O+C)/2 作为开盘价
+	want := []KlineData{
+		{StartTime: 1000, Open: 10.5, High: 12, Low: 9, Close: 10.5, Volume: 5},
+		{StartTime: 2000, Open: 10.5, High: 14, Low: 10, Close: 12, Volume: 6},
+		{StartTime: 3000, Open: 11.25, High: 13.5, Low: 8, Close: 10.875, Volume: 7, Unclosed: true},
+	}
+	if len(ha) != len(want) {
+		t.Fatalf("got %d candles, want %d", len(ha), len(want))
+	}
+	for i, w := range want {
+		g := *ha[i]
+		if math.Abs(g.Open-w.Open) > 1e-9 || math.Abs(g.High-w.High) > 1e-9 || math.Abs(g.Low-w.Low) > 1e-9 ||
+			math.Abs(g.Close-w.Close) > 1e-9 || g.StartTime != w.StartTime || g.Volume != w.Volume || g.Unclosed != w.Unclosed {
+			t.Errorf("candle %d = %+v, want %+v", i, g, w)
+		}
+		if ha[i] == input[i] {
+			t.Errorf("candle %d shares the input pointer", i)
+		}
+	}
+
+	for i, kline := range input {
+		if !reflect.DeepEqual(*kline, snapshot[i]) {
+			t.Errorf("input candle %d modified: %+v, was %+v", i, *kline, snapshot[i])
+		}
+	}
+
+	if KlineDatas(nil).HeikinAshi() != nil {
+		t.Error("empty input should return nil")
+	}
+}
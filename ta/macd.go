@@ -19,6 +19,7 @@ type TaMacd struct {
 	ShortPeriod  int       `json:"short_period"`  // 短期EMA周期
 	LongPeriod   int       `json:"long_period"`   // 长期EMA周期
 	SignalPeriod int       `json:"signal_period"` // 信号线周期
+	ValidFrom    int       `json:"valid_from"`    // DEA与MACD柱首个有效值的索引，DIF从longPeriod-1开始有效
 }
 
 // CalculateMACD 计算MACD指标
@@ -34,6 +35,9 @@ type TaMacd struct {
 //	   DEA = DIF的N日EMA（通常9日）
 //	4. 计算MACD柱：
 //	   MACD = 2 * (DIF - DEA)
+//	预热区间：
+//	- DIF 首个有效值位于索引 longPeriod-1
+//	- DEA 与 MACD 柱首个有效值位于索引 longPeriod+signalPeriod-2，之前为0
 //	使用场景：
 //	- 判断趋势方向和强度
 //	- 寻找买卖点
@@ -72,13 +76,14 @@ func CalculateMACD(prices []float64, shortPeriod, longPeriod, signalPeriod int)
 		}
 	}
 
-	dea, err := CalculateEMA(dif, signalPeriod)
+	// 信号线从DIF首个有效值开始计算，避免预热区的0值拉低种子
+	dea, err := CalculateEMAFrom(dif, signalPeriod, longPeriod-1)
 	if err != nil {
 		return nil, err
 	}
 
 	macd := make([]float64, len(prices))
-	for i := 0; i < len(prices); i++ {
+	for i := longPeriod + signalPeriod - 2; i < len(prices); i++ {
 		macd[i] = 2 * (dif[i] - dea.Values[i]) / 2
 	}
 	return &TaMacd{
@@ -88,6 +93,7 @@ func CalculateMACD(prices []float64, shortPeriod, longPeriod, signalPeriod int)
 		ShortPeriod:  shortPeriod,
 		LongPeriod:   longPeriod,
 		SignalPeriod: signalPeriod,
+		ValidFrom:    longPeriod + signalPeriod - 2,
 	}, nil
 }
 
@@ -153,6 +159,13 @@ func (t *TaMacd) Value() (macd, dif, dea float64) {
 	return t.Macd[lastIndex], t.Dif[lastIndex], t.Dea[lastIndex]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaMacd) ContainsInvalid() bool {
+	return containsInvalid(t.Macd, t.Dif, t.Dea)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
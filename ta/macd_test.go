@@ -0,0 +1,72 @@
+package ta
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateEMAFrom(t *testing.T) {
+	prices := []float64{0, 0, 2, 4, 6, 8}
+	ema, err := CalculateEMAFrom(prices, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 种子为 prices[2..4] 的简单平均，之后 k = 2/(3+1) = 0.5
+	assertSeries(t, "EMAFrom", ema.Values, 0, []float64{0, 0, 0, 0, 4, 6}, 1e-12)
+	if ema.ValidFrom != 4 {
+		t.Errorf("ValidFrom = %d, want 4", ema.ValidFrom)
+	}
+	if _, err := CalculateEMAFrom(prices, 5, 2); err == nil {
+		t.Error("expected error when fewer than period values follow start")
+	}
+}
+
+func TestCalculateMACDSignalSeed(t *testing.T) {
+	const short, long, signal = 3, 5, 3
+	macd, err := CalculateMACD(stockChartsRSICloses, short, long, signal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	difStart := long - 1
+	validFrom := long + signal - 2
+	if macd.ValidFrom != validFrom {
+		t.Fatalf("ValidFrom = %d, want %d", macd.ValidFrom, validFrom)
+	}
+	for i := 0; i < difStart; i++ {
+		if macd.Dif[i] != 0 {
+			t.Errorf("DIF[%d] = %v in warm-up, want 0", i, macd.Dif[i])
+		}
+	}
+	for i := 0; i < validFrom; i++ {
+		if macd.Dea[i] != 0 || macd.Macd[i] != 0 {
+			t.Errorf("DEA/MACD[%d] = %v/%v in warm-up, want 0", i, macd.Dea[i], macd.Macd[i])
+		}
+	}
+
+	// DEA 的种子是DIF预热结束后首个signal个有效值的平均，不包含预热区的0
+	var sum float64
+	for i := difStart; i <= validFrom; i++ {
+		sum += macd.Dif[i]
+	}
+	seed := sum / signal
+	if math.Abs(macd.Dea[validFrom]-seed) > 1e-12 {
+		t.Fatalf("DEA seed = %v, want %v", macd.Dea[validFrom], seed)
+	}
+	if macd.Dif[difStart] == 0 {
+		t.Fatal("test series must have a non-zero first DIF")
+	}
+
+	k := 2.0 / float64(signal+1)
+	prev := seed
+	for i := validFrom + 1; i < len(stockChartsRSICloses); i++ {
+		want := macd.Dif[i]*k + prev*(1-k)
+		if math.Abs(macd.Dea[i]-want) > 1e-12 {
+			t.Fatalf("DEA[%d] = %v, want %v", i, macd.Dea[i], want)
+		}
+		if math.Abs(macd.Macd[i]-(macd.Dif[i]-macd.Dea[i])) > 1e-12 {
+			t.Fatalf("MACD[%d] = %v, want DIF-DEA", i, macd.Macd[i])
+		}
+		prev = want
+	}
+}
@@ -17,7 +17,8 @@ import (
 //	- 帮助判断趋势的强弱
 //	- 适合寻找主力资金进出的迹象
 type TaOBV struct {
-	Values []float64 `json:"values"` // OBV值序列
+	Values    []float64 `json:"values"`     // OBV值序列
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引（OBV无预热区，始终为0）
 }
 
 // CalculateOBV 计算能量潮指标
@@ -122,6 +123,79 @@ func (t *TaOBV) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaOBV) ContainsInvalid() bool {
+	return containsInvalid(t.Values)
+}
+
+// divergencePivotPeriod 背离检测中确认轴点所需的左右K线数量
+const divergencePivotPeriod = 2
+
+// Divergence 检测最近lookback根K线内的OBV与价格背离
+// 说明：
+//
+//	在价格序列中寻找最近两个轴点（左右各2根K线确认），并比较对应位置的OBV：
+//	- 底背离：价格低点更低，OBV低点更高，返回1
+//	- 顶背离：价格高点更高，OBV高点更低，返回-1
+//	同时存在时以最近一个轴点所属的背离为准
+//	轴点需要右侧K线确认，因此信号会滞后2根K线
+//
+// 参数：
+//   - prices: 计算OBV时使用的价格序列（通常为收盘价），长度需与OBV一致
+//   - lookback: 向前查找轴点的K线数量，通常为20-60
+//
+// 返回值：
+//   - int: 1表示底背离，-1表示顶背离，0表示无背离或数据不足
+//
+// 示例：
+//
+//	obv, _ := klines.OBV()
+//	closes, _ := klines.ExtractSlice("close")
+//	signal := obv.Divergence(closes, 30)
+func (t *TaOBV) Divergence(prices []float64, lookback int) int {
+	n := len(prices)
+	if n != len(t.Values) || lookback < divergencePivotPeriod*2+2 {
+		return 0
+	}
+
+	start := n - lookback
+	if start < 0 {
+		start = 0
+	}
+
+	lows, highs := make([]int, 0, 2), make([]int, 0, 2)
+	for i := n - 1 - divergencePivotPeriod; i >= start+divergencePivotPeriod; i-- {
+		if len(lows) < 2 && isPivotLow(prices, i, divergencePivotPeriod) {
+			lows = append(lows, i)
+		}
+		if len(highs) < 2 && isPivotHigh(prices, i, divergencePivotPeriod) {
+			highs = append(highs, i)
+		}
+		if len(lows) == 2 && len(highs) == 2 {
+			break
+		}
+	}
+
+	// lows[0]、highs[0] 为最近的轴点，lows[1]、highs[1] 为前一个轴点
+	bullish := len(lows) == 2 && prices[lows[0]] < prices[lows[1]] && t.Values[lows[0]] > t.Values[lows[1]]
+	bearish := len(highs) == 2 && prices[highs[0]] > prices[highs[1]] && t.Values[highs[0]] < t.Values[highs[1]]
+
+	switch {
+	case bullish && bearish:
+		if lows[0] > highs[0] {
+			return 1
+		}
+		return -1
+	case bullish:
+		return 1
+	case bearish:
+		return -1
+	}
+	return 0
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
@@ -0,0 +1,134 @@
+package ta
+
+import (
+	"math"
+)
+
+// K线形态识别的阈值，均为相对比例
+const (
+	patternDojiBodyRatio  = 0.1 // 实体不超过振幅的该比例时视为十字星
+	patternLongWickRatio  = 2.0 // 锤子线/射击之星的长影线至少为实体的该倍数
+	patternShortWickRatio = 0.1 // 锤子线/射击之星的短影线不超过振幅的该比例
+	patternStarLongRatio  = 0.5 // 启明星/黄昏星首根K线的实体至少为振幅的该比例
+	patternStarSmallRatio = 0.3 // 启明星/黄昏星中间K线的实体不超过首根实体的该比例
+)
+
+// PatternHit 表示识别到的一个K线形态
+type PatternHit struct {
+	Index     int    `json:"index"`     // 形态最后一根K线的索引
+	Name      string `json:"name"`      // 形态名称
+	Direction int    `json:"direction"` // 形态方向：1看涨，-1看跌，0中性
+}
+
+// candleShape K线实体与影线的长度
+type candleShape struct {
+	body, upper, lower, rng float64
+	bullish, bearish        bool
+}
+
+// shapeOf 根据OHLC计算K线实体与上下影线
+func shapeOf(kline *KlineData) candleShape {
+	top := math.Max(kline.Open, kline.Close)
+	bottom := math.Min(kline.Open, kline.Close)
+	return candleShape{
+		body:    top - bottom,
+		upper:   kline.High - top,
+		lower:   bottom - kline.Low,
+		rng:     kline.High - kline.Low,
+		bullish: kline.Close > kline.Open,
+		bearish: kline.Close < kline.Open,
+	}
+}
+
+// DetectPatterns 识别K线数据中的常见蜡烛图形态
+// 说明：
+//
+//	基于实体与影线的比例识别以下形态，阈值见 pattern* 常量：
+//	- doji: 十字星，实体极小，方向为0
+//	- hammer: 锤子线，下影线长、上影线短，方向为1
+//	- shooting_star: 射击之星，上影线长、下影线短，方向为-1
+//	- bullish_engulfing / bearish_engulfing: 看涨/看跌吞没，当前实体完全覆盖前一根反向实体
+//	- morning_star / evening_star: 启明星/黄昏星，长实体 + 小实体 + 反向K线收复首根实体一半以上
+//	只根据K线本身的形状判断，不考虑所处趋势，使用时建议结合趋势指标确认
+//	同一根K线可能同时命中多个形态，结果按索引升序排列
+//
+// 参数：
+//   - klineData: K线数据
+//
+// 返回值：
+//   - []PatternHit: 识别到的形态列表，没有命中时返回nil
+//
+// 示例：
+//
+//	for _, hit := range ta.DetectPatterns(klines) {
+//		fmt.Println(hit.Index, hit.Name, hit.Direction)
+//	}
+func DetectPatterns(klineData KlineDatas) []PatternHit {
+	var hits []PatternHit
+	for i, kline := range klineData {
+		if kline == nil {
+			continue
+		}
+		curr := shapeOf(kline)
+		if curr.rng <= 0 {
+			continue
+		}
+
+		// 单根K线形态
+		if curr.body <= curr.rng*patternDojiBodyRatio {
+			hits = append(hits, PatternHit{Index: i, Name: "doji", Direction: 0})
+		}
+		if curr.body > 0 && curr.lower >= curr.body*patternLongWickRatio && curr.upper <= curr.rng*patternShortWickRatio {
+			hits = append(hits, PatternHit{Index: i, Name: "hammer", Direction: 1})
+		}
+		if curr.body > 0 && curr.upper >= curr.body*patternLongWickRatio && curr.lower <= curr.rng*patternShortWickRatio {
+			hits = append(hits, PatternHit{Index: i, Name: "shooting_star", Direction: -1})
+		}
+
+		// 两根K线形态
+		if i < 1 || klineData[i-1] == nil {
+			continue
+		}
+		prevKline := klineData[i-1]
+		prev := shapeOf(prevKline)
+		if prev.bearish && curr.bullish && curr.body > prev.body &&
+			kline.Open <= prevKline.Close && kline.Close >= prevKline.Open {
+			hits = append(hits, PatternHit{Index: i, Name: "bullish_engulfing", Direction: 1})
+		}
+		if prev.bullish && curr.bearish && curr.body > prev.body &&
+			kline.Open >= prevKline.Close && kline.Close <= prevKline.Open {
+			hits = append(hits, PatternHit{Index: i, Name: "bearish_engulfing", Direction: -1})
+		}
+
+		// 三根K线形态
+		if i < 2 || klineData[i-2] == nil {
+			continue
+		}
+		firstKline := klineData[i-2]
+		first := shapeOf(firstKline)
+		if first.rng <= 0 || first.body < first.rng*patternStarLongRatio || prev.body > first.body*patternStarSmallRatio {
+			continue
+		}
+		firstMid := (firstKline.Open + firstKline.Close) / 2
+		if first.bearish && curr.bullish && kline.Close > firstMid {
+			hits = append(hits, PatternHit{Index: i, Name: "morning_star", Direction: 1})
+		}
+		if first.bullish && curr.bearish && kline.Close < firstMid {
+			hits = append(hits, PatternHit{Index: i, Name: "evening_star", Direction: -1})
+		}
+	}
+	return hits
+}
+
+// Patterns 识别K线数据中的常见蜡烛图形态
+// 返回值：
+//   - []PatternHit: 识别到的形态列表
+func (k *KlineDatas) Patterns() []PatternHit {
+	return DetectPatterns(*k)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,160 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// dayMillis 一天的毫秒数
+const dayMillis = int64(24 * 60 * 60 * 1000)
+
+// TaPivotPoints 表示枢轴点(Pivot Points)的计算结果
+// 说明：
+//
+//	枢轴点根据上一周期（通常为前一日）的最高价、最低价、收盘价计算当期的支撑与阻力位：
+//	- PP: 枢轴点
+//	- R1-R3: 阻力位，由近到远
+//	- S1-S3: 支撑位，由近到远
+//	支持的计算方法：
+//	- classic: 经典枢轴点
+//	- fibonacci: 以斐波那契比例划分波幅
+//	- camarilla: 以收盘价为中心的窄幅区间，适合日内反转交易
+type TaPivotPoints struct {
+	Method    string  `json:"method"`     // 计算方法
+	PP        float64 `json:"pp"`         // 枢轴点
+	R1        float64 `json:"r1"`         // 第一阻力位
+	R2        float64 `json:"r2"`         // 第二阻力位
+	R3        float64 `json:"r3"`         // 第三阻力位
+	S1        float64 `json:"s1"`         // 第一支撑位
+	S2        float64 `json:"s2"`         // 第二支撑位
+	S3        float64 `json:"s3"`         // 第三支撑位
+	StartTime int64   `json:"start_time"` // 适用周期的开始时间（毫秒），DailyPivots计算时有效
+}
+
+// CalculatePivotPoints 根据上一周期的高低收计算枢轴点
+// 说明：
+//
+//	classic:
+//	  PP = (H + L + C) / 3，R1 = 2PP - L，S1 = 2PP - H
+//	  R2 = PP + (H - L)，S2 = PP - (H - L)，R3 = H + 2(PP - L)，S3 = L - 2(H - PP)
+//	fibonacci:
+//	  PP = (H + L + C) / 3，R/S = PP ± (H - L) * 0.382 / 0.618 / 1
+//	camarilla:
+//	  PP = (H + L + C) / 3，R/S = C ± (H - L) * 1.1 / 12 / 6 / 4
+//
+// 参数：
+//   - prevHigh: 上一周期最高价
+//   - prevLow: 上一周期最低价
+//   - prevClose: 上一周期收盘价
+//   - method: 计算方法，支持"classic"、"fibonacci"、"camarilla"，为空时使用classic
+//
+// 返回值：
+//   - *TaPivotPoints: 包含枢轴点计算结果的结构体指针
+//   - error: 参数无效或方法不支持时返回错误
+//
+// 示例：
+//
+//	pivots, err := CalculatePivotPoints(70000, 68000, 69500, "classic")
+func CalculatePivotPoints(prevHigh, prevLow, prevClose float64, method string) (*TaPivotPoints, error) {
+	if prevHigh < prevLow {
+		return nil, fmt.Errorf("最高价(%v)不能小于最低价(%v)", prevHigh, prevLow)
+	}
+	if method == "" {
+		method = "classic"
+	}
+
+	diff := prevHigh - prevLow
+	pp := (prevHigh + prevLow + prevClose) / 3
+	result := &TaPivotPoints{Method: method, PP: pp}
+
+	switch method {
+	case "classic":
+		result.R1 = 2*pp - prevLow
+		result.S1 = 2*pp - prevHigh
+		result.R2 = pp + diff
+		result.S2 = pp - diff
+		result.R3 = prevHigh + 2*(pp-prevLow)
+		result.S3 = prevLow - 2*(prevHigh-pp)
+	case "fibonacci":
+		result.R1 = pp + diff*0.382
+		result.S1 = pp - diff*0.382
+		result.R2 = pp + diff*0.618
+		result.S2 = pp - diff*0.618
+		result.R3 = pp + diff
+		result.S3 = pp - diff
+	case "camarilla":
+		result.R1 = prevClose + diff*1.1/12
+		result.S1 = prevClose - diff*1.1/12
+		result.R2 = prevClose + diff*1.1/6
+		result.S2 = prevClose - diff*1.1/6
+		result.R3 = prevClose + diff*1.1/4
+		result.S3 = prevClose - diff*1.1/4
+	default:
+		return nil, fmt.Errorf("不支持的枢轴点计算方法: %s", method)
+	}
+
+	return result, nil
+}
+
+// utcDayStart 返回时间戳（毫秒）所在UTC自然日的开始时间
+func utcDayStart(ms int64) int64 {
+	day := ms / dayMillis
+	if ms < 0 && ms%dayMillis != 0 {
+		day--
+	}
+	return day * dayMillis
+}
+
+// DailyPivots 计算当日的枢轴点
+// 说明：
+//
+//	按 StartTime（毫秒）将K线划分为UTC自然日，以最后一根K线所在日为当日，
+//	取数据中当日之前最近一个交易日的最高价、最低价与最后收盘价计算枢轴点
+//	K线需按时间升序排列，周期需小于一天
+//
+// 参数：
+//   - method: 计算方法，支持"classic"、"fibonacci"、"camarilla"，为空时使用classic
+//
+// 返回值：
+//   - *TaPivotPoints: 当日的枢轴点，StartTime 为当日UTC零点
+//   - error: 没有前一交易日数据或方法不支持时返回错误
+//
+// 示例：
+//
+//	pivots, err := klines.DailyPivots("camarilla")
+func (k KlineDatas) DailyPivots(method string) (*TaPivotPoints, error) {
+	if len(k) == 0 {
+		return nil, fmt.Errorf("没有K线数据")
+	}
+
+	today := utcDayStart(k[len(k)-1].StartTime)
+
+	// 从后往前找到前一交易日的最后一根K线
+	end := len(k) - 1
+	for end >= 0 && utcDayStart(k[end].StartTime) >= today {
+		end--
+	}
+	if end < 0 {
+		return nil, fmt.Errorf("缺少前一交易日的K线数据")
+	}
+
+	prevDay := utcDayStart(k[end].StartTime)
+	high, low := math.Inf(-1), math.Inf(1)
+	for i := end; i >= 0 && utcDayStart(k[i].StartTime) == prevDay; i-- {
+		high = math.Max(high, k[i].High)
+		low = math.Min(low, k[i].Low)
+	}
+
+	pivots, err := CalculatePivotPoints(high, low, k[end].Close, method)
+	if err != nil {
+		return nil, err
+	}
+	pivots.StartTime = today
+	return pivots, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -17,8 +17,9 @@ import (
 //	- 计算过程中不会丢失历史信息
 //	- 适合用于波动较大的市场
 type TaRMA struct {
-	Values []float64 `json:"values"` // RMA值序列
-	Period int       `json:"period"` // 计算周期
+	Values    []float64 `json:"values"`     // RMA值序列
+	Period    int       `json:"period"`     // 计算周期
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引（以首个价格为种子，从0开始有效）
 }
 
 // CalculateRMA 计算平滑移动平均线
@@ -66,8 +67,9 @@ func CalculateRMA(prices []float64, period int) (*TaRMA, error) {
 	}
 
 	return &TaRMA{
-		Values: rma,
-		Period: period,
+		Values:    rma,
+		Period:    period,
+		ValidFrom: 0,
 	}, nil
 }
 
@@ -122,6 +124,13 @@ func (t *TaRMA) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaRMA) ContainsInvalid() bool {
+	return containsInvalid(t.Values)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
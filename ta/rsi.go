@@ -13,24 +13,54 @@ import (
 //	- RSI > 70 通常被认为是超买状态
 //	- RSI < 30 通常被认为是超卖状态
 type TaRSI struct {
-	Values []float64 `json:"values"` // RSI值的时间序列
-	Period int       `json:"period"` // 计算RSI使用的周期
-	Gains  []float64 `json:"gains"`  // 价格上涨幅度的时间序列
-	Losses []float64 `json:"losses"` // 价格下跌幅度的时间序列
+	Values    []float64    `json:"values"`     // RSI值的时间序列
+	Period    int          `json:"period"`     // 计算RSI使用的周期
+	Gains     []float64    `json:"gains"`      // 价格上涨幅度的时间序列
+	Losses    []float64    `json:"losses"`     // 价格下跌幅度的时间序列
+	Smoothing RSISmoothing `json:"smoothing"`  // 平均涨跌幅的平滑方式
+	ValidFrom int          `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
+}
+
+// RSISmoothing RSI平均涨跌幅的平滑方式
+// 说明：
+//
+//	不同平台的RSI实现并不一致，两种方式的结果差异足以改变超买超卖信号：
+//	- RSIWilder: Wilder平滑（即RMA），TradingView、币安等多数平台使用，默认方式
+//	- RSISMA: 周期内涨跌幅的简单平均（Cutler's RSI），部分平台与旧版软件使用
+type RSISmoothing string
+
+const (
+	RSIWilder RSISmoothing = "wilder" // Wilder平滑，以首个周期的简单平均为种子，之后 avg = (avg*(n-1) + x) / n
+	RSISMA    RSISmoothing = "sma"    // 最近n个涨跌幅的简单平均
+)
+
+// rsiSmoothingOf 返回可选参数中的平滑方式，未指定时为 RSIWilder
+func rsiSmoothingOf(smoothing []RSISmoothing) (RSISmoothing, error) {
+	if len(smoothing) == 0 || smoothing[0] == "" {
+		return RSIWilder, nil
+	}
+	switch smoothing[0] {
+	case RSIWilder, RSISMA:
+		return smoothing[0], nil
+	default:
+		return "", fmt.Errorf("不支持的RSI平滑方式: %s", smoothing[0])
+	}
 }
 
 // CalculateRSI 计算给定价格序列的RSI指标
 // 说明：
 //
-//	使用Wilder的RSI计算方法，包括以下步骤：
+//	默认使用Wilder的RSI计算方法，包括以下步骤：
 //	1. 计算每日价格变动的涨跌幅
 //	2. 计算初始平均涨幅和跌幅
 //	3. 使用平滑移动平均计算后续的平均涨幅和跌幅
 //	4. 根据公式 RSI = 100 - (100 / (1 + RS)) 计算RSI值，其中RS = 平均涨幅/平均跌幅
+//	smoothing 为 RSISMA 时，第3步改为取最近period个涨跌幅的简单平均
 //
 // 参数：
 //   - prices: 价格时间序列
 //   - period: RSI计算周期
+//   - smoothing: 可选，平滑方式，默认 RSIWilder
 //
 // 返回值：
 //   - *TaRSI: 包含RSI计算结果的结构体指针
@@ -40,8 +70,14 @@ type TaRSI struct {
 //
 //	prices := []float64{10, 10.5, 10.3, 10.2, 10.4, 10.3, 10.7}
 //	rsi, err := CalculateRSI(prices, 5)
-func CalculateRSI(prices []float64, period int) (*TaRSI, error) {
-	if len(prices) < period {
+//	cutler, err := CalculateRSI(prices, 5, RSISMA)
+func CalculateRSI(prices []float64, period int, smoothing ...RSISmoothing) (*TaRSI, error) {
+	mode, err := rsiSmoothingOf(smoothing)
+	if err != nil {
+		return nil, err
+	}
+	// 首个RSI需要period个涨跌幅，即period+1个价格
+	if period <= 0 || len(prices) <= period {
 		return nil, fmt.Errorf("计算数据不足")
 	}
 
@@ -66,8 +102,19 @@ func CalculateRSI(prices []float64, period int) (*TaRSI, error) {
 
 	for i := period; i < length; i++ {
 		if i > period {
-			avgGain = (avgGain*(float64(period)-1) + gains[i]) / float64(period)
-			avgLoss = (avgLoss*(float64(period)-1) + losses[i]) / float64(period)
+			if mode == RSISMA {
+				// 每次重新求和，避免滑动加减的浮点误差使平均跌幅出现接近0的负数
+				avgGain, avgLoss = 0, 0
+				for j := i - period + 1; j <= i; j++ {
+					avgGain += gains[j]
+					avgLoss += losses[j]
+				}
+				avgGain /= float64(period)
+				avgLoss /= float64(period)
+			} else {
+				avgGain = (avgGain*(float64(period)-1) + gains[i]) / float64(period)
+				avgLoss = (avgLoss*(float64(period)-1) + losses[i]) / float64(period)
+			}
 		}
 
 		if avgLoss == 0 {
@@ -79,10 +126,12 @@ func CalculateRSI(prices []float64, period int) (*TaRSI, error) {
 	}
 
 	return &TaRSI{
-		Values: rsi,
-		Period: period,
-		Gains:  gains,
-		Losses: losses,
+		Values:    rsi,
+		Period:    period,
+		Gains:     gains,
+		Losses:    losses,
+		Smoothing: mode,
+		ValidFrom: period,
 	}, nil
 }
 
@@ -94,34 +143,35 @@ func CalculateRSI(prices []float64, period int) (*TaRSI, error) {
 // 参数：
 //   - period: RSI计算周期
 //   - source: 价格数据来源，可以是"close"、"open"、"high"、"low"等
+//   - smoothing: 可选，平滑方式，默认 RSIWilder
 //
 // 返回值：
 //   - *TaRSI: 包含RSI计算结果的结构体指针
 //   - error: 计算过程中的错误
-func (k *KlineDatas) RSI(period int, source string) (*TaRSI, error) {
+func (k *KlineDatas) RSI(period int, source string, smoothing ...RSISmoothing) (*TaRSI, error) {
 	prices, err := k.ExtractSlice(source)
 	if err != nil {
 		return nil, err
 	}
-	return CalculateRSI(prices, period)
+	return CalculateRSI(prices, period, smoothing...)
 }
 
 // RSI_ 获取最新的RSI值
 // 参数：
 //   - period: RSI计算周期
 //   - source: 价格数据来源，可以是"close"、"open"、"high"、"low"等
+//   - smoothing: 可选，平滑方式，默认 RSIWilder
 //
 // 返回值：
 //   - float64: 最新的RSI值
-func (k *KlineDatas) RSI_(period int, source string) float64 {
-	rsi, err := k.RSI(period, source)
+func (k *KlineDatas) RSI_(period int, source string, smoothing ...RSISmoothing) float64 {
+	rsi, err := k.RSI(period, source, smoothing...)
 	if err != nil {
 		return 0
 	}
 	return rsi.Value()
 }
 
-
 // Value 获取最新的RSI值
 // 说明：
 //
@@ -133,6 +183,13 @@ func (t *TaRSI) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaRSI) ContainsInvalid() bool {
+	return containsInvalid(t.Values, t.Gains, t.Losses)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
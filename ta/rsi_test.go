@@ -0,0 +1,70 @@
+package ta
+
+import (
+	"math"
+	"testing"
+)
+
+// StockCharts RSI 教程中的14周期示例数据
+var stockChartsRSICloses = []float64{
+	44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08, 45.89,
+	46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64, 46.21, 46.25,
+	45.71, 46.45, 45.78, 45.35, 44.03, 44.18, 44.22, 44.57, 43.42, 42.66, 43.13,
+}
+
+func assertSeries(t *testing.T, name string, got []float64, from int, want []float64, tolerance float64) {
+	t.Helper()
+	if len(got)-from != len(want) {
+		t.Fatalf("%s: got %d values from index %d, want %d", name, len(got)-from, from, len(want))
+	}
+	for i, w := range want {
+		if g := got[from+i]; math.Abs(g-w) > tolerance {
+			t.Errorf("%s[%d] = %.4f, want %.4f (±%g)", name, from+i, g, w, tolerance)
+		}
+	}
+}
+
+func TestCalculateRSIWilderStockCharts(t *testing.T) {
+	// StockCharts 公布的值由四舍五入后的平均涨跌幅算出，与精确计算最多相差约0.07
+	want := []float64{
+		70.53, 66.32, 66.55, 69.41, 66.36, 57.97, 62.93, 63.26, 56.06, 62.38,
+		54.71, 50.42, 39.99, 41.46, 41.87, 45.46, 37.30, 33.08, 37.77,
+	}
+	rsi, err := CalculateRSI(stockChartsRSICloses, 14, RSIWilder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rsi.ValidFrom != 14 || rsi.Smoothing != RSIWilder {
+		t.Fatalf("ValidFrom = %d, Smoothing = %q", rsi.ValidFrom, rsi.Smoothing)
+	}
+	assertSeries(t, "RSIWilder", rsi.Values, 14, want, 0.1)
+
+	// 未指定平滑方式时默认使用 Wilder
+	def, err := CalculateRSI(stockChartsRSICloses, 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSeries(t, "default", def.Values, 14, rsi.Values[14:], 0)
+}
+
+func TestCalculateRSISMA(t *testing.T) {
+	// 按最近14个涨跌幅的简单平均手工计算，保留4位小数
+	want := []float64{
+		70.4641, 70.0210, 69.8312, 80.5677, 73.3333, 59.8063, 62.5282, 60.0000, 48.4778, 53.8784,
+		48.9524, 43.8628, 37.7329, 32.2635, 32.7181, 38.1426, 31.7483, 25.0996, 30.2177,
+	}
+	rsi, err := CalculateRSI(stockChartsRSICloses, 14, RSISMA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSeries(t, "RSISMA", rsi.Values, 14, want, 1e-3)
+}
+
+func TestCalculateRSIInsufficientData(t *testing.T) {
+	if _, err := CalculateRSI(stockChartsRSICloses[:14], 14); err == nil {
+		t.Fatal("expected error for period prices")
+	}
+	if _, err := CalculateRSI(stockChartsRSICloses, 14, RSISmoothing("ema")); err == nil {
+		t.Fatal("expected error for unknown smoothing")
+	}
+}
@@ -14,8 +14,9 @@ import (
 //	- 能有效过滤价格噪音
 //	- 滞后性较强，适合确认中长期趋势
 type TaSMA struct {
-	Values []float64 `json:"values"` // SMA值的时间序列
-	Period int       `json:"period"` // 计算SMA使用的周期
+	Values    []float64 `json:"values"`     // SMA值的时间序列
+	Period    int       `json:"period"`     // 计算SMA使用的周期
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
 }
 
 // CalculateSMA 计算给定价格序列的简单移动平均线
@@ -60,8 +61,9 @@ func CalculateSMA(prices []float64, period int) (*TaSMA, error) {
 	}
 
 	return &TaSMA{
-		Values: sma,
-		Period: period,
+		Values:    sma,
+		Period:    period,
+		ValidFrom: period - 1,
 	}, nil
 }
 
@@ -111,6 +113,13 @@ func (t *TaSMA) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaSMA) ContainsInvalid() bool {
+	return containsInvalid(t.Values)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
@@ -0,0 +1,236 @@
+package ta
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SnapshotConfig 指标快照配置
+// 说明：
+//
+//	周期为0的指标不参与计算
+//	Source 为空时默认使用收盘价
+type SnapshotConfig struct {
+	Source string `json:"source"` // 价格类型，用于RSI、MACD、EMA、Boll
+
+	RSIPeriod int `json:"rsi_period"` // RSI周期
+
+	MACDShort  int `json:"macd_short"`  // MACD短期EMA周期
+	MACDLong   int `json:"macd_long"`   // MACD长期EMA周期
+	MACDSignal int `json:"macd_signal"` // MACD信号线周期
+
+	ADXPeriod int `json:"adx_period"` // ADX周期
+	ATRPeriod int `json:"atr_period"` // ATR周期
+	CCIPeriod int `json:"cci_period"` // CCI周期
+
+	BollPeriod int     `json:"boll_period"` // 布林带周期
+	BollStdDev float64 `json:"boll_std"`    // 布林带标准差倍数
+
+	KDJRsvPeriod int `json:"kdj_rsv_period"` // KDJ RSV周期
+	KDJKPeriod   int `json:"kdj_k_period"`   // KDJ K值周期
+	KDJDPeriod   int `json:"kdj_d_period"`   // KDJ D值周期
+
+	SuperTrendPeriod     int     `json:"supertrend_period"`     // SuperTrend ATR周期
+	SuperTrendMultiplier float64 `json:"supertrend_multiplier"` // SuperTrend ATR乘数
+
+	EMAPeriods []int `json:"ema_periods"` // 需要计算的EMA周期列表
+}
+
+// DefaultSnapshotConfig 返回常用参数的快照配置
+func DefaultSnapshotConfig() SnapshotConfig {
+	return SnapshotConfig{
+		Source:               "close",
+		RSIPeriod:            14,
+		MACDShort:            12,
+		MACDLong:             26,
+		MACDSignal:           9,
+		ADXPeriod:            14,
+		ATRPeriod:            14,
+		CCIPeriod:            20,
+		BollPeriod:           20,
+		BollStdDev:           2,
+		KDJRsvPeriod:         9,
+		KDJKPeriod:           3,
+		KDJDPeriod:           3,
+		SuperTrendPeriod:     10,
+		SuperTrendMultiplier: 3,
+		EMAPeriods:           []int{20, 50, 200},
+	}
+}
+
+// IndicatorSnapshot 多个指标在最新一根K线上的取值
+// 说明：
+//
+//	交叉信号取值：1 表示上穿（金叉），-1 表示下穿（死叉），0 表示无交叉
+//	未计算或被跳过的指标保持零值，可通过 Computed / Skipped 判断
+type IndicatorSnapshot struct {
+	StartTime int64   `json:"startTime"` // 最新K线开始时间
+	Close     float64 `json:"close"`     // 最新收盘价
+
+	RSI float64 `json:"rsi"`
+
+	MACD      float64 `json:"macd"`
+	MACDDif   float64 `json:"macd_dif"`
+	MACDDea   float64 `json:"macd_dea"`
+	MACDCross int     `json:"macd_cross"` // DIF与DEA的交叉
+
+	ADX     float64 `json:"adx"`
+	PlusDI  float64 `json:"plus_di"`
+	MinusDI float64 `json:"minus_di"`
+	DICross int     `json:"di_cross"` // +DI与-DI的交叉
+
+	ATR float64 `json:"atr"`
+	CCI float64 `json:"cci"`
+
+	BollUpper float64 `json:"boll_upper"`
+	BollMid   float64 `json:"boll_mid"`
+	BollLower float64 `json:"boll_lower"`
+
+	KDJK     float64 `json:"kdj_k"`
+	KDJD     float64 `json:"kdj_d"`
+	KDJJ     float64 `json:"kdj_j"`
+	KDJCross int     `json:"kdj_cross"` // K与D的交叉
+
+	SuperTrendUpper float64 `json:"supertrend_upper"`
+	SuperTrendLower float64 `json:"supertrend_lower"`
+	SuperTrend      int     `json:"supertrend"`      // 当前趋势：1上涨，-1下跌
+	SuperTrendFlip  int     `json:"supertrend_flip"` // 最新K线发生的趋势反转方向
+
+	EMA      map[int]float64 `json:"ema"`       // 按周期索引的EMA值
+	EMACross int             `json:"ema_cross"` // 最短与最长EMA的交叉（至少配置两个周期）
+
+	Computed []string          `json:"computed"` // 成功计算的指标名称
+	Skipped  map[string]string `json:"skipped"`  // 被跳过的指标及原因
+}
+
+// Snapshot 一次性计算多个指标并返回最新值快照
+// 说明：
+//
+//	复用各指标的计算函数，价格序列只提取一次
+//	数据不足等原因导致计算失败的指标会被跳过并记录在 Skipped 中，不影响其他指标
+//
+// 参数：
+//   - cfg: 快照配置，可使用 DefaultSnapshotConfig() 获取常用参数
+//
+// 返回值：
+//   - *IndicatorSnapshot: 指标快照
+//   - error: K线数据为空或价格类型不支持时返回错误
+//
+// 示例：
+//
+//	snap, err := klines.Snapshot(ta.DefaultSnapshotConfig())
+func (k *KlineDatas) Snapshot(cfg SnapshotConfig) (*IndicatorSnapshot, error) {
+	if len(*k) == 0 {
+		return nil, fmt.Errorf("没有K线数据")
+	}
+	source := cfg.Source
+	if source == "" {
+		source = "close"
+	}
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+
+	last := (*k)[len(*k)-1]
+	snap := &IndicatorSnapshot{
+		StartTime: last.StartTime,
+		Close:     last.Close,
+		Skipped:   make(map[string]string),
+	}
+	record := func(name string, err error) bool {
+		if err != nil {
+			snap.Skipped[name] = err.Error()
+			return false
+		}
+		snap.Computed = append(snap.Computed, name)
+		return true
+	}
+
+	if cfg.RSIPeriod > 0 {
+		rsi, err := CalculateRSI(prices, cfg.RSIPeriod)
+		if record("rsi", err) {
+			snap.RSI = rsi.Value()
+		}
+	}
+
+	if cfg.MACDShort > 0 && cfg.MACDLong > 0 && cfg.MACDSignal > 0 {
+		macd, err := CalculateMACD(prices, cfg.MACDShort, cfg.MACDLong, cfg.MACDSignal)
+		if record("macd", err) {
+			snap.MACD, snap.MACDDif, snap.MACDDea = macd.Value()
+			snap.MACDCross = Cross(macd.Dif, macd.Dea)
+		}
+	}
+
+	if cfg.ADXPeriod > 0 {
+		adx, err := CalculateADX(*k, cfg.ADXPeriod)
+		if record("adx", err) {
+			snap.ADX, snap.PlusDI, snap.MinusDI = adx.Value()
+			snap.DICross = adx.CrossOver()
+		}
+	}
+
+	if cfg.ATRPeriod > 0 {
+		atr, err := CalculateATR(*k, cfg.ATRPeriod)
+		if record("atr", err) {
+			snap.ATR = atr.Value()
+		}
+	}
+
+	if cfg.CCIPeriod > 0 {
+		cci, err := CalculateCCI(*k, cfg.CCIPeriod)
+		if record("cci", err) {
+			snap.CCI = cci.Value()
+		}
+	}
+
+	if cfg.BollPeriod > 0 {
+		boll, err := CalculateBoll(prices, cfg.BollPeriod, cfg.BollStdDev)
+		if record("boll", err) {
+			snap.BollUpper, snap.BollMid, snap.BollLower = boll.Value()
+		}
+	}
+
+	if cfg.KDJRsvPeriod > 0 {
+		kdj, err := k.KDJ(cfg.KDJRsvPeriod, cfg.KDJKPeriod, cfg.KDJDPeriod)
+		if record("kdj", err) {
+			snap.KDJK, snap.KDJD, snap.KDJJ = kdj.Value()
+			snap.KDJCross = Cross(kdj.K, kdj.D)
+		}
+	}
+
+	if cfg.SuperTrendPeriod > 0 {
+		st, err := CalculateSuperTrend(*k, cfg.SuperTrendPeriod, cfg.SuperTrendMultiplier)
+		if record("supertrend", err) {
+			snap.SuperTrendUpper, snap.SuperTrendLower, snap.SuperTrend = st.Value()
+			if n := len(st.Trend); n >= 2 && st.Trend[n-2] != 0 && st.Trend[n-1] != st.Trend[n-2] {
+				snap.SuperTrendFlip = st.Trend[n-1]
+			}
+		}
+	}
+
+	if len(cfg.EMAPeriods) > 0 {
+		snap.EMA = make(map[int]float64, len(cfg.EMAPeriods))
+		emas := make(map[int]*TaEMA, len(cfg.EMAPeriods))
+		for _, period := range cfg.EMAPeriods {
+			if _, ok := emas[period]; ok || period <= 0 {
+				continue
+			}
+			ema, err := CalculateEMA(prices, period)
+			if record(fmt.Sprintf("ema%d", period), err) {
+				emas[period] = ema
+				snap.EMA[period] = ema.Value()
+			}
+		}
+		if len(emas) >= 2 {
+			periods := make([]int, 0, len(emas))
+			for period := range emas {
+				periods = append(periods, period)
+			}
+			sort.Ints(periods)
+			snap.EMACross = Cross(emas[periods[0]].Values, emas[periods[len(periods)-1]].Values)
+		}
+	}
+
+	return snap, nil
+}
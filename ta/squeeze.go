@@ -0,0 +1,211 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaSqueeze 表示布林带-肯特纳通道挤压(TTM Squeeze)的计算结果
+// 说明：
+//
+//	挤压指标通过比较布林带与肯特纳通道判断波动率收缩：
+//	1. 布林带完全收进肯特纳通道内时为挤压状态，波动率处于低位
+//	2. 布林带重新扩张到通道之外时挤压释放，往往伴随一段单边行情
+//	3. 动量柱用于判断释放后的方向
+//	状态取值：
+//	- 0: 挤压中（布林带在肯特纳通道内）
+//	- 1: 挤压释放（上一根处于挤压中，当前根布林带已扩张到通道外）
+//	- -1: 无挤压（包括预热区）
+type TaSqueeze struct {
+	State      []int     `json:"state"`       // 挤压状态序列：0挤压中，1挤压释放，-1无挤压
+	Momentum   []float64 `json:"momentum"`    // 动量柱序列
+	BollPeriod int       `json:"boll_period"` // 布林带周期
+	BollStdDev float64   `json:"boll_std"`    // 布林带标准差倍数
+	KcPeriod   int       `json:"kc_period"`   // 肯特纳通道周期（EMA与ATR共用）
+	KcMult     float64   `json:"kc_mult"`     // 肯特纳通道ATR乘数
+	ValidFrom  int       `json:"valid_from"`  // 首个有效值的索引，之前为预热区
+}
+
+// CalculateSqueeze 计算布林带-肯特纳通道挤压指标
+// 说明：
+//
+//	计算步骤：
+//	1. 使用收盘价计算布林带(bollPeriod, bollStd)
+//	2. 计算肯特纳通道(kcPeriod, kcPeriod, kcMult)
+//	3. 布林带上轨低于通道上轨且下轨高于通道下轨时判定为挤压中
+//	4. 动量 = 线性回归(收盘价 - ((N周期最高价与最低价的中点 + 收盘价SMA) / 2), N)，N为kcPeriod
+//
+// 参数：
+//   - klineData: K线数据
+//   - bollPeriod: 布林带周期，通常为20
+//   - bollStd: 布林带标准差倍数，通常为2
+//   - kcPeriod: 肯特纳通道周期，通常为20
+//   - kcMult: 肯特纳通道ATR乘数，通常为1.5
+//
+// 返回值：
+//   - *TaSqueeze: 包含挤压指标计算结果的结构体指针
+//   - error: 计算过程中的错误，如数据不足等
+//
+// 示例：
+//
+//	squeeze, err := CalculateSqueeze(klineData, 20, 2.0, 20, 1.5)
+func CalculateSqueeze(klineData KlineDatas, bollPeriod int, bollStd float64, kcPeriod int, kcMult float64) (*TaSqueeze, error) {
+	length := len(klineData)
+	if bollPeriod <= 0 || kcPeriod <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	if length < bollPeriod || length < kcPeriod*2-1 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	closes, err := klineData.ExtractSlice("close")
+	if err != nil {
+		return nil, err
+	}
+
+	boll, err := CalculateBoll(closes, bollPeriod, bollStd)
+	if err != nil {
+		return nil, err
+	}
+
+	kc, err := CalculateKeltner(klineData, kcPeriod, kcPeriod, kcMult)
+	if err != nil {
+		return nil, err
+	}
+
+	// 动量的基准：收盘价相对 (唐奇安中线 + SMA) / 2 的偏离
+	sma, err := CalculateSMA(closes, kcPeriod)
+	if err != nil {
+		return nil, err
+	}
+	delta := make([]float64, length)
+	for i := kcPeriod - 1; i < length; i++ {
+		highest, lowest := klineData[i].High, klineData[i].Low
+		for j := i - kcPeriod + 1; j < i; j++ {
+			highest = math.Max(highest, klineData[j].High)
+			lowest = math.Min(lowest, klineData[j].Low)
+		}
+		delta[i] = closes[i] - ((highest+lowest)/2+sma.Values[i])/2
+	}
+
+	momentumFrom := kcPeriod*2 - 2
+	validFrom := max(boll.ValidFrom, kc.ValidFrom, momentumFrom)
+
+	momentum := make([]float64, length)
+	for i := momentumFrom; i < length; i++ {
+		momentum[i] = linregLast(delta[i-kcPeriod+1 : i+1])
+	}
+
+	state := make([]int, length)
+	prevOn := false
+	for i := 0; i < length; i++ {
+		if i < validFrom {
+			state[i] = -1
+			continue
+		}
+		on := boll.Upper[i] < kc.Upper[i] && boll.Lower[i] > kc.Lower[i]
+		switch {
+		case on:
+			state[i] = 0
+		case prevOn:
+			state[i] = 1
+		default:
+			state[i] = -1
+		}
+		prevOn = on
+	}
+
+	return &TaSqueeze{
+		State:      state,
+		Momentum:   momentum,
+		BollPeriod: bollPeriod,
+		BollStdDev: bollStd,
+		KcPeriod:   kcPeriod,
+		KcMult:     kcMult,
+		ValidFrom:  validFrom,
+	}, nil
+}
+
+// linregLast 计算序列最小二乘线性回归在最后一个点上的拟合值
+func linregLast(values []float64) float64 {
+	n := float64(len(values))
+	if n == 0 {
+		return 0
+	}
+	if n == 1 {
+		return values[0]
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	for i, v := range values {
+		x := float64(i)
+		sumX += x
+		sumY += v
+		sumXY += x * v
+		sumXX += x * x
+	}
+	slope := (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+	intercept := (sumY - slope*sumX) / n
+	return intercept + slope*(n-1)
+}
+
+// Squeeze 为K线数据计算布林带-肯特纳通道挤压指标
+// 参数：
+//   - bollPeriod: 布林带周期
+//   - bollStd: 布林带标准差倍数
+//   - kcPeriod: 肯特纳通道周期
+//   - kcMult: 肯特纳通道ATR乘数
+//
+// 返回值：
+//   - *TaSqueeze: 包含挤压指标计算结果的结构体指针
+//   - error: 计算过程中的错误
+func (k *KlineDatas) Squeeze(bollPeriod int, bollStd float64, kcPeriod int, kcMult float64) (*TaSqueeze, error) {
+	return CalculateSqueeze(*k, bollPeriod, bollStd, kcPeriod, kcMult)
+}
+
+// Squeeze_ 获取最新的挤压状态和动量值
+// 参数：
+//   - bollPeriod: 布林带周期
+//   - bollStd: 布林带标准差倍数
+//   - kcPeriod: 肯特纳通道周期
+//   - kcMult: 肯特纳通道ATR乘数
+//
+// 返回值：
+//   - int: 最新的挤压状态，计算失败时返回-1
+//   - float64: 最新的动量值
+func (k *KlineDatas) Squeeze_(bollPeriod int, bollStd float64, kcPeriod int, kcMult float64) (int, float64) {
+	squeeze, err := k.Squeeze(bollPeriod, bollStd, kcPeriod, kcMult)
+	if err != nil {
+		return -1, 0
+	}
+	return squeeze.Value()
+}
+
+// Value 获取最新的挤压状态和动量值
+// 说明：
+//
+//	返回最新一根K线的挤压状态和动量
+//	使用建议：
+//	- 状态为1且动量大于0时，可视为向上释放
+//	- 状态为1且动量小于0时，可视为向下释放
+//	- 状态持续为0时等待释放，不宜追单
+//
+// 返回值：
+//   - state: 挤压状态，0挤压中，1挤压释放，-1无挤压
+//   - momentum: 动量值
+func (t *TaSqueeze) Value() (state int, momentum float64) {
+	lastIndex := len(t.State) - 1
+	return t.State[lastIndex], t.Momentum[lastIndex]
+}
+
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaSqueeze) ContainsInvalid() bool {
+	return containsInvalid(t.Momentum)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -23,6 +23,7 @@ type TaStochRSI struct {
 	StochPeriod int       `json:"stoch_period"` // 随机指标计算周期
 	KPeriod     int       `json:"k_period"`     // K值平滑周期
 	DPeriod     int       `json:"d_period"`     // D值平滑周期
+	ValidFrom   int       `json:"valid_from"`   // D值首个有效值的索引，之前为预热区
 }
 
 // CalculateStochRSI 计算给定价格序列的随机RSI指标
@@ -40,6 +41,7 @@ type TaStochRSI struct {
 //   - stochPeriod: 随机值计算周期，通常为14
 //   - kPeriod: K值平滑周期，通常为3
 //   - dPeriod: D值平滑周期，通常为3
+//   - smoothing: 可选，RSI的平滑方式，默认 RSIWilder，见 RSISmoothing
 //
 // 返回值：
 //   - *TaStochRSI: 包含StochRSI计算结果的结构体指针
@@ -49,12 +51,12 @@ type TaStochRSI struct {
 //
 //	prices := []float64{10, 10.5, 10.3, 10.2, 10.4, 10.3, 10.7}
 //	stochRsi, err := CalculateStochRSI(prices, 14, 14, 3, 3)
-func CalculateStochRSI(prices []float64, rsiPeriod, stochPeriod, kPeriod, dPeriod int) (*TaStochRSI, error) {
+func CalculateStochRSI(prices []float64, rsiPeriod, stochPeriod, kPeriod, dPeriod int, smoothing ...RSISmoothing) (*TaStochRSI, error) {
 	if len(prices) < rsiPeriod+stochPeriod {
 		return nil, fmt.Errorf("计算数据不足")
 	}
 
-	rsi, err := CalculateRSI(prices, rsiPeriod)
+	rsi, err := CalculateRSI(prices, rsiPeriod, smoothing...)
 	if err != nil {
 		return nil, err
 	}
@@ -113,6 +115,7 @@ func CalculateStochRSI(prices []float64, rsiPeriod, stochPeriod, kPeriod, dPerio
 		StochPeriod: stochPeriod,
 		KPeriod:     kPeriod,
 		DPeriod:     dPeriod,
+		ValidFrom:   rsiPeriod + stochPeriod + kPeriod + dPeriod - 3,
 	}, nil
 }
 
@@ -127,16 +130,17 @@ func CalculateStochRSI(prices []float64, rsiPeriod, stochPeriod, kPeriod, dPerio
 //   - kPeriod: K值平滑周期
 //   - dPeriod: D值平滑周期
 //   - source: 价格数据来源，可以是"close"、"open"、"high"、"low"等
+//   - smoothing: 可选，RSI的平滑方式，默认 RSIWilder
 //
 // 返回值：
 //   - *TaStochRSI: 包含StochRSI计算结果的结构体指针
 //   - error: 计算过程中的错误
-func (k *KlineDatas) StochRSI(rsiPeriod, stochPeriod, kPeriod, dPeriod int, source string) (*TaStochRSI, error) {
+func (k *KlineDatas) StochRSI(rsiPeriod, stochPeriod, kPeriod, dPeriod int, source string, smoothing ...RSISmoothing) (*TaStochRSI, error) {
 	prices, err := k.ExtractSlice(source)
 	if err != nil {
 		return nil, err
 	}
-	return CalculateStochRSI(prices, rsiPeriod, stochPeriod, kPeriod, dPeriod)
+	return CalculateStochRSI(prices, rsiPeriod, stochPeriod, kPeriod, dPeriod, smoothing...)
 }
 
 // StochRSI_ 获取最新的StochRSI的K值和D值
@@ -146,12 +150,13 @@ func (k *KlineDatas) StochRSI(rsiPeriod, stochPeriod, kPeriod, dPeriod int, sour
 //   - kPeriod: K值平滑周期
 //   - dPeriod: D值平滑周期
 //   - source: 价格数据来源，可以是"close"、"open"、"high"、"low"等
+//   - smoothing: 可选，RSI的平滑方式，默认 RSIWilder
 //
 // 返回值：
 //   - float64: 最新的K值
 //   - float64: 最新的D值
-func (k *KlineDatas) StochRSI_(rsiPeriod, stochPeriod, kPeriod, dPeriod int, source string) (float64, float64) {
-	stochRsi, err := k.StochRSI(rsiPeriod, stochPeriod, kPeriod, dPeriod, source)
+func (k *KlineDatas) StochRSI_(rsiPeriod, stochPeriod, kPeriod, dPeriod int, source string, smoothing ...RSISmoothing) (float64, float64) {
+	stochRsi, err := k.StochRSI(rsiPeriod, stochPeriod, kPeriod, dPeriod, source, smoothing...)
 	if err != nil {
 		return 0, 0
 	}
@@ -175,6 +180,13 @@ func (t *TaStochRSI) Value() (kValue, dValue float64) {
 	return t.K[lastIndex], t.D[lastIndex]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaStochRSI) ContainsInvalid() bool {
+	return containsInvalid(t.K, t.D)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
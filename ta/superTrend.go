@@ -24,6 +24,7 @@ type TaSuperTrend struct {
 	Lower      []float64 `json:"lower_band"` // 下轨线序列
 	Period     int       `json:"period"`     // ATR计算周期
 	Multiplier float64   `json:"multiplier"` // ATR乘数，用于调整轨道宽度
+	ValidFrom  int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
 }
 
 // CalculateSuperTrend 计算给定K线数据的超级趋势指标
@@ -38,6 +39,8 @@ type TaSuperTrend struct {
 //	趋势判断规则：
 //	- 当收盘价上穿上轨线时，趋势转为上涨
 //	- 当收盘价下穿下轨线时，趋势转为下跌
+//	- 索引period之前为预热区，轨道与趋势为0，不参与趋势判断
+//	- 首根有效K线（索引period）以收盘价相对中点确定初始趋势
 //
 // 参数：
 //   - klineData: K线数据
@@ -74,7 +77,10 @@ func CalculateSuperTrend(klineData KlineDatas, period int, multiplier float64) (
 		lowerBand[i] = midpoint - multiplier*atrValue
 	}
 
-	if klineData[period].Close > lowerBand[period] {
+	// 初始趋势以首根有效K线的收盘价相对中点判断
+	// 下轨恒低于中点，若与下轨比较几乎总会得到上涨趋势
+	midpoint := (klineData[period].High + klineData[period].Low) / 2
+	if klineData[period].Close >= midpoint {
 		trend[period] = 1
 	} else {
 		trend[period] = -1
@@ -118,6 +124,7 @@ func CalculateSuperTrend(klineData KlineDatas, period int, multiplier float64) (
 		Lower:      lowerBand,
 		Period:     period,
 		Multiplier: multiplier,
+		ValidFrom:  period,
 	}, nil
 }
 
@@ -172,6 +179,13 @@ func (t *TaSuperTrend) Value() (upper, lower float64, trend int) {
 	return t.Upper[lastIndex], t.Lower[lastIndex], t.Trend[lastIndex]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaSuperTrend) ContainsInvalid() bool {
+	return containsInvalid(t.Values, t.Upper, t.Lower)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
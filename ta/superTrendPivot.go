@@ -25,6 +25,7 @@ type TaSuperTrendPivot struct {
 	PivotPeriod int       `json:"pivot_period"` // 寻找轴点的周期范围
 	Factor      float64   `json:"factor"`       // ATR乘数，用于调整轨道宽度
 	AtrPeriod   int       `json:"atr_period"`   // ATR计算周期
+	ValidFrom   int       `json:"valid_from"`   // 首个有效值的索引，之前为预热区
 }
 
 // FindPivotHighPoint 在指定周期范围内寻找高点轴点
@@ -77,6 +78,32 @@ func FindPivotLowPoint(klineData KlineDatas, index, period int) float64 {
 	return klineData[index].Low
 }
 
+// isPivotHigh 判断序列在index位置是否为高点轴点，规则与 FindPivotHighPoint 相同
+func isPivotHigh(values []float64, index, period int) bool {
+	if index < period || index+period >= len(values) {
+		return false
+	}
+	for i := index - period; i <= index+period; i++ {
+		if values[i] > values[index] {
+			return false
+		}
+	}
+	return true
+}
+
+// isPivotLow 判断序列在index位置是否为低点轴点，规则与 FindPivotLowPoint 相同
+func isPivotLow(values []float64, index, period int) bool {
+	if index < period || index+period >= len(values) {
+		return false
+	}
+	for i := index - period; i <= index+period; i++ {
+		if values[i] < values[index] {
+			return false
+		}
+	}
+	return true
+}
+
 // CalculateSuperTrendPivot 计算基于轴点的超级趋势指标
 // 说明：
 //
@@ -200,6 +227,7 @@ func CalculateSuperTrendPivot(klineData KlineDatas, pivotPeriod int, factor floa
 		PivotPeriod: pivotPeriod,
 		Factor:      factor,
 		AtrPeriod:   atrPeriod,
+		ValidFrom:   max(pivotPeriod, atrPeriod) + 1,
 	}, nil
 }
 
@@ -257,6 +285,13 @@ func (t *TaSuperTrendPivot) Value() (upper, lower float64, trend int) {
 	return t.Upper[lastIndex], t.Lower[lastIndex], t.Trend[lastIndex]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaSuperTrendPivot) ContainsInvalid() bool {
+	return containsInvalid(t.Values, t.Upper, t.Lower)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
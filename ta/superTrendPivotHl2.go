@@ -23,6 +23,7 @@ type TaSuperTrendPivotHl2 struct {
 	Lower      []float64 `json:"lower_band"` // 下轨线序列
 	Period     int       `json:"period"`     // ATR计算周期
 	Multiplier float64   `json:"multiplier"` // ATR乘数，用于调整轨道宽度
+	ValidFrom  int       `json:"valid_from"` // 首个有效值的索引，之前为预热区
 }
 
 // CalculateSuperTrendPivotHl2 计算基于HL2的超级趋势指标
@@ -39,6 +40,8 @@ type TaSuperTrendPivotHl2 struct {
 //	趋势判断规则：
 //	- 当收盘价上穿上轨时，趋势转为上涨
 //	- 当收盘价下穿下轨时，趋势转为下跌
+//	- 索引period之前为预热区，趋势为0，轨道不参与趋势判断
+//	- 首根有效K线（索引period）以收盘价相对中点确定初始趋势
 //
 // 参数：
 //   - klineData: K线数据
@@ -83,6 +86,20 @@ func CalculateSuperTrendPivotHl2(klineData KlineDatas, period int, multiplier fl
 		basicUpperBand := hl2 + multiplier*atr.Values[i]
 		basicLowerBand := hl2 - multiplier*atr.Values[i]
 
+		// 预热区的ATR为0，轨道没有参考意义，首根有效K线直接使用基础轨道并以中点判断初始趋势
+		if i == period {
+			upperBand[i] = basicUpperBand
+			lowerBand[i] = basicLowerBand
+			if klineData[i].Close >= hl2 {
+				trend[i] = 1
+				values[i] = lowerBand[i]
+			} else {
+				trend[i] = -1
+				values[i] = upperBand[i]
+			}
+			continue
+		}
+
 		if basicLowerBand > lowerBand[i-1] || klineData[i-1].Close < lowerBand[i-1] {
 			lowerBand[i] = basicLowerBand
 		} else {
@@ -123,6 +140,7 @@ func CalculateSuperTrendPivotHl2(klineData KlineDatas, period int, multiplier fl
 		Lower:      lowerBand,
 		Period:     period,
 		Multiplier: multiplier,
+		ValidFrom:  period,
 	}, nil
 }
 
@@ -179,6 +197,13 @@ func (t *TaSuperTrendPivotHl2) Value() (upper, lower float64, trend int) {
 	return t.Upper[last], t.Lower[last], t.Trend[last]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaSuperTrendPivotHl2) ContainsInvalid() bool {
+	return containsInvalid(t.Values, t.Upper, t.Lower)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
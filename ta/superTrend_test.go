@@ -0,0 +1,65 @@
+package ta
+
+import (
+	"math"
+	"testing"
+)
+
+// rangeKlines 生成高低价差恒为2的K线，真实波幅恒为2，ATR因此也恒为2
+func rangeKlines(closes []float64) KlineDatas {
+	klines := make(KlineDatas, len(closes))
+	for i, c := range closes {
+		klines[i] = &KlineData{StartTime: int64(i) * 60000, Open: 101, High: 102, Low: 100, Close: c, Volume: 1}
+	}
+	return klines
+}
+
+func TestCalculateSuperTrendInitialTrend(t *testing.T) {
+	const period, multiplier = 5, 3.0
+	cases := []struct {
+		name      string
+		close     float64 // 首根有效K线（索引period）的收盘价，中点为101
+		wantTrend int
+		wantValue float64
+	}{
+		// 收盘价高于中点：上涨，值为下轨 101 - 3*2
+		{"close above midpoint", 101.5, 1, 95},
+		// 收盘价低于中点但远高于下轨：下跌，值为上轨 101 + 3*2
+		{"close below midpoint", 100.5, -1, 107},
+	}
+
+	for _, c := range cases {
+		closes := make([]float64, 10)
+		for i := range closes {
+			closes[i] = 101.5
+		}
+		closes[period] = c.close
+
+		st, err := CalculateSuperTrend(rangeKlines(closes), period, multiplier)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+
+		first := -1
+		for i, trend := range st.Trend {
+			if trend != 0 {
+				first = i
+				break
+			}
+		}
+		if first != period || st.ValidFrom != period {
+			t.Fatalf("%s: first non-zero trend index = %d, ValidFrom = %d, want %d", c.name, first, st.ValidFrom, period)
+		}
+		for i := 0; i < period; i++ {
+			if st.Values[i] != 0 || st.Upper[i] != 0 || st.Lower[i] != 0 {
+				t.Errorf("%s: warm-up index %d not zero", c.name, i)
+			}
+		}
+		if st.Trend[period] != c.wantTrend {
+			t.Errorf("%s: trend = %d, want %d", c.name, st.Trend[period], c.wantTrend)
+		}
+		if math.Abs(st.Values[period]-c.wantValue) > 1e-9 {
+			t.Errorf("%s: value = %v, want %v", c.name, st.Values[period], c.wantValue)
+		}
+	}
+}
@@ -18,9 +18,10 @@ import (
 //	- 可通过参数调整灵敏度
 //	- 计算复杂但效果优异
 type TaT3 struct {
-	Values []float64 `json:"values"` // T3移动平均线的值序列
-	Period int       `json:"period"` // 计算周期
-	VFact  float64   `json:"vfact"`  // 体积因子，用于调整平滑度
+	Values    []float64 `json:"values"`     // T3移动平均线的值序列
+	Period    int       `json:"period"`     // 计算周期
+	VFact     float64   `json:"vfact"`      // 体积因子，用于调整平滑度
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
 }
 
 // CalculateT3 计算Tillson T3移动平均线
@@ -88,9 +89,10 @@ func CalculateT3(prices []float64, period int, vfact float64) (*TaT3, error) {
 	}
 
 	return &TaT3{
-		Values: t3,
-		Period: period,
-		VFact:  vfact,
+		Values:    t3,
+		Period:    period,
+		VFact:     vfact,
+		ValidFrom: period * 6,
 	}, nil
 }
 
@@ -147,6 +149,13 @@ func (t *TaT3) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaT3) ContainsInvalid() bool {
+	return containsInvalid(t.Values)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
@@ -3,6 +3,7 @@ package ta
 import (
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"runtime"
 	"strconv"
@@ -16,12 +17,13 @@ import (
 //	- 开盘时间
 //	- OHLCV (开盘价、最高价、最低价、收盘价、成交量)
 type KlineData struct {
-	StartTime int64   `json:"startTime"` // K线的开始时间戳（毫秒）
-	Open      float64 `json:"open"`      // 开盘价
-	High      float64 `json:"high"`      // 最高价
-	Low       float64 `json:"low"`       // 最低价
-	Close     float64 `json:"close"`     // 收盘价
-	Volume    float64 `json:"volume"`    // 成交量
+	StartTime int64   `json:"startTime"`          // K线的开始时间戳（毫秒）
+	Open      float64 `json:"open"`               // 开盘价
+	High      float64 `json:"high"`               // 最高价
+	Low       float64 `json:"low"`                // 最低价
+	Close     float64 `json:"close"`              // 收盘价
+	Volume    float64 `json:"volume"`             // 成交量
+	Unclosed  bool    `json:"unclosed,omitempty"` // 是否为尚未收盘的K线，默认false即已收盘
 }
 
 // KlineDatas 是KlineData的切片类型，代表一组K线数据
@@ -36,6 +38,79 @@ type FieldNames struct {
 	LowFields    []string // 自定义最低价字段名称
 	CloseFields  []string // 自定义收盘价字段名称
 	VolumeFields []string // 自定义成交量字段名称
+	TimeUnit     TimeUnit // 时间字段的单位，默认 TimeUnitAuto 按位数自动识别
+	KeepUnclosed bool     // NewKlineDatas 的 l 为 true 时保留最后一根K线并标记 Unclosed，而不是丢弃
+}
+
+// TimeUnit 时间戳单位
+// 说明：
+//
+//	KlineData.StartTime 统一为毫秒，不同数据源的时间戳会按此单位换算
+//	如Gate返回秒级时间戳，部分数据源返回微秒级时间戳
+type TimeUnit int
+
+const (
+	TimeUnitAuto        TimeUnit = iota // 按位数自动识别：小于1e11视为秒，小于1e14视为毫秒，小于1e17视为微秒，否则视为纳秒
+	TimeUnitSecond                      // 秒
+	TimeUnitMillisecond                 // 毫秒
+	TimeUnitMicrosecond                 // 微秒
+	TimeUnitNanosecond                  // 纳秒
+)
+
+// toMillis 将指定单位的时间戳换算为毫秒
+func (u TimeUnit) toMillis(ts int64) int64 {
+	switch u.resolve(ts) {
+	case TimeUnitSecond:
+		return ts * 1000
+	case TimeUnitMicrosecond:
+		return ts / 1000
+	case TimeUnitNanosecond:
+		return ts / 1e6
+	default:
+		return ts
+	}
+}
+
+// floatToMillis 将带小数的时间戳换算为毫秒，小数部分按单位换算而不是直接截断
+func (u TimeUnit) floatToMillis(ts float64) int64 {
+	switch u.resolve(int64(ts)) {
+	case TimeUnitSecond:
+		return int64(math.Round(ts * 1000))
+	case TimeUnitMicrosecond:
+		return int64(ts / 1000)
+	case TimeUnitNanosecond:
+		return int64(ts / 1e6)
+	default:
+		return int64(ts)
+	}
+}
+
+// resolve 返回时间戳的实际单位，TimeUnitAuto 时按位数识别
+func (u TimeUnit) resolve(ts int64) TimeUnit {
+	if u != TimeUnitAuto {
+		return u
+	}
+	if ts < 0 {
+		ts = -ts
+	}
+	switch {
+	case ts < 1e11:
+		return TimeUnitSecond
+	case ts < 1e14:
+		return TimeUnitMillisecond
+	case ts < 1e17:
+		return TimeUnitMicrosecond
+	default:
+		return TimeUnitNanosecond
+	}
+}
+
+// timeUnitOf 返回自定义字段配置中的时间单位，未配置时为自动识别
+func timeUnitOf(customFields *FieldNames) TimeUnit {
+	if customFields == nil {
+		return TimeUnitAuto
+	}
+	return customFields.TimeUnit
 }
 
 // klineExtractor 定义K线数据提取器函数类型
@@ -660,11 +735,12 @@ func max(values ...int) int {
 //
 //	将任意格式的K线数据转换为标准的KlineDatas格式
 //	支持并发处理大量数据，自动根据CPU核心数分配工作
+//	StartTime 统一换算为毫秒，单位由 customFields 的 TimeUnit 指定，默认按位数自动识别
 //
 // 参数：
 //   - klines: 输入的K线数据（支持多种格式）
-//   - l: 是否排除最后一根K线（通常用于处理未完成的K线）
-//   - customFields: 可选的自定义字段名称，用于扩展支持的字段名称
+//   - l: 是否排除最后一根K线（通常用于处理未完成的K线），customFields 设置 KeepUnclosed 时改为保留并标记 Unclosed
+//   - customFields: 可选的自定义字段名称、时间单位等配置，用于扩展支持的字段名称
 //
 // 返回值：
 //   - KlineDatas: 标准格式的K线数据集合
@@ -675,8 +751,15 @@ func NewKlineDatas(klines interface{}, l bool, customFields ...*FieldNames) (Kli
 		return nil, fmt.Errorf("输入必须是切片类型")
 	}
 
+	// 处理自定义字段配置
+	var customFieldsPtr *FieldNames
+	if len(customFields) > 0 && customFields[0] != nil {
+		customFieldsPtr = customFields[0]
+	}
+	keepUnclosed := l && customFieldsPtr != nil && customFieldsPtr.KeepUnclosed
+
 	length := v.Len()
-	if l && length > 0 {
+	if l && length > 0 && !keepUnclosed {
 		length--
 	}
 	if length == 0 {
@@ -696,12 +779,6 @@ func NewKlineDatas(klines interface{}, l bool, customFields ...*FieldNames) (Kli
 	var extractor klineExtractor
 	var err error
 
-	// 处理自定义字段配置
-	var customFieldsPtr *FieldNames
-	if len(customFields) > 0 && customFields[0] != nil {
-		customFieldsPtr = customFields[0]
-	}
-
 	if isArrayFormat {
 		// 数组格式：获取或创建提取器
 		extractor = getArrayExtractor(customFieldsPtr)
@@ -715,6 +792,8 @@ func NewKlineDatas(klines interface{}, l bool, customFields ...*FieldNames) (Kli
 		extractor = cache.extractor
 	}
 
+	timeUnit := timeUnitOf(customFieldsPtr)
+
 	// 提取单条K线转换函数，直接使用预生成的提取器
 	convertKlineItem := func(index int) error {
 		item := v.Index(index)
@@ -722,6 +801,7 @@ func NewKlineDatas(klines interface{}, l bool, customFields ...*FieldNames) (Kli
 		if extractErr != nil {
 			return fmt.Errorf("处理第%d条数据时出错: %v", index+1, extractErr)
 		}
+		klineData.StartTime = timeUnit.toMillis(klineData.StartTime)
 		klineDataList[index] = klineData
 		return nil
 	}
@@ -786,6 +866,82 @@ func NewKlineDatas(klines interface{}, l bool, customFields ...*FieldNames) (Kli
 		}
 	}
 
+	if keepUnclosed {
+		klineDataList[length-1].Unclosed = true
+	}
+	return klineDataList, nil
+}
+
+// KlineArrayLayout 数组格式K线的字段位置配置
+// 说明：
+//
+//	指定各字段在位置数组中的索引，如币安 [openTime, open, high, low, close, volume, ...]
+//	可通过 DefaultKlineArrayLayout() 获取币安/Bybit使用的默认顺序
+type KlineArrayLayout struct {
+	Time   int `json:"time"`   // 开始时间的索引
+	Open   int `json:"open"`   // 开盘价的索引
+	High   int `json:"high"`   // 最高价的索引
+	Low    int `json:"low"`    // 最低价的索引
+	Close  int `json:"close"`  // 收盘价的索引
+	Volume int `json:"volume"` // 成交量的索引
+
+	TimeUnit TimeUnit `json:"time_unit"` // 时间戳单位，默认 TimeUnitAuto 按位数自动识别
+}
+
+// DefaultKlineArrayLayout 返回默认的数组字段顺序
+// 说明：
+//
+//	[time, open, high, low, close, volume]，与币安、Bybit的K线接口一致
+func DefaultKlineArrayLayout() KlineArrayLayout {
+	return KlineArrayLayout{Time: 0, Open: 1, High: 2, Low: 3, Close: 4, Volume: 5}
+}
+
+// NewKlineDatasFromArrays 从位置数组格式的K线创建K线数据集合
+// 说明：
+//
+//	适用于交易所REST接口返回的无字段名K线，如 json.Unmarshal 得到的 [][]interface{}
+//	元素支持数字和数字字符串，转换规则与 NewKlineDatas 的数组格式一致
+//	StartTime 按 layout.TimeUnit 换算为毫秒，默认按位数自动识别
+//
+// 参数：
+//   - raw: 位置数组格式的K线数据
+//   - layout: 字段位置配置
+//
+// 返回值：
+//   - KlineDatas: 标准格式的K线数据集合
+//   - error: 字段索引无效或转换失败时返回错误
+//
+// 示例：
+//
+//	var raw [][]interface{}
+//	_ = json.Unmarshal(body, &raw)
+//	klines, err := ta.NewKlineDatasFromArrays(raw, ta.DefaultKlineArrayLayout())
+func NewKlineDatasFromArrays(raw [][]interface{}, layout KlineArrayLayout) (KlineDatas, error) {
+	if len(raw) == 0 {
+		return nil, errors.New("没有K线数据")
+	}
+	if layout.Time < 0 || layout.Open < 0 || layout.High < 0 || layout.Low < 0 || layout.Close < 0 || layout.Volume < 0 {
+		return nil, fmt.Errorf("无效的字段索引: %+v", layout)
+	}
+
+	extractor := generateArrayExtractor(&arrayFieldIndexes{
+		timeIndex:   layout.Time,
+		openIndex:   layout.Open,
+		highIndex:   layout.High,
+		lowIndex:    layout.Low,
+		closeIndex:  layout.Close,
+		volumeIndex: layout.Volume,
+	})
+
+	klineDataList := make(KlineDatas, len(raw))
+	for i, row := range raw {
+		klineData, err := extractor(reflect.ValueOf(row))
+		if err != nil {
+			return nil, fmt.Errorf("处理第%d条数据时出错: %v", i+1, err)
+		}
+		klineData.StartTime = layout.TimeUnit.toMillis(klineData.StartTime)
+		klineDataList[i] = klineData
+	}
 	return klineDataList, nil
 }
 
@@ -877,11 +1033,47 @@ func (k *KlineDatas) Add(wsKline interface{}, customFields ...*FieldNames) error
 	if err != nil {
 		return err
 	}
+	klineData.StartTime = timeUnitOf(customFieldsPtr).toMillis(klineData.StartTime)
 
 	*k = append(*k, klineData)
 	return nil
 }
 
+// safeDivide 安全除法
+// 说明：
+//
+//	分母为0或结果为NaN/Inf时返回fallback，避免平盘等退化数据产生的无效值传播到指标结果中
+//
+// 参数：
+//   - numerator: 分子
+//   - denominator: 分母
+//   - fallback: 无法得到有效结果时的返回值
+//
+// 返回值：
+//   - float64: 除法结果或fallback
+func safeDivide(numerator, denominator, fallback float64) float64 {
+	if denominator == 0 {
+		return fallback
+	}
+	result := numerator / denominator
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return fallback
+	}
+	return result
+}
+
+// containsInvalid 检查序列中是否包含NaN或Inf
+func containsInvalid(series ...[]float64) bool {
+	for _, values := range series {
+		for _, v := range values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // preallocateSlices 预分配多个float64切片
 // 说明：
 //
@@ -0,0 +1,114 @@
+package ta
+
+import (
+	"math"
+	"testing"
+)
+
+type invalidChecker interface {
+	ContainsInvalid() bool
+}
+
+// constantKlines 生成价格完全不变的K线，用于检查除零保护
+func constantKlines(n int, price, volume float64) KlineDatas {
+	klines := make(KlineDatas, n)
+	for i := range klines {
+		klines[i] = &KlineData{
+			StartTime: int64(i) * 60000,
+			Open:      price,
+			High:      price,
+			Low:       price,
+			Close:     price,
+			Volume:    volume,
+		}
+	}
+	return klines
+}
+
+// indicatorSweep 返回所有带 ContainsInvalid 的指标，新增指标时需加入此处
+func indicatorSweep(k KlineDatas) map[string]func() (invalidChecker, error) {
+	wrap := func(v invalidChecker, err error) (invalidChecker, error) { return v, err }
+	return map[string]func() (invalidChecker, error){
+		"ADX":                func() (invalidChecker, error) { return wrap(k.ADX(14)) },
+		"Aroon":              func() (invalidChecker, error) { return wrap(k.Aroon(14)) },
+		"ATR":                func() (invalidChecker, error) { return wrap(k.ATR(14)) },
+		"Boll":               func() (invalidChecker, error) { return wrap(k.Boll(20, 2, "close")) },
+		"CCI":                func() (invalidChecker, error) { return wrap(k.CCI(20)) },
+		"CMF":                func() (invalidChecker, error) { return wrap(k.CMF(20, "close")) },
+		"CMO":                func() (invalidChecker, error) { return wrap(k.CMO(14, "close")) },
+		"Donchian":           func() (invalidChecker, error) { return wrap(k.Donchian(20)) },
+		"DPO":                func() (invalidChecker, error) { return wrap(k.DPO("close", 12, 26, 9, 3)) },
+		"EMA":                func() (invalidChecker, error) { return wrap(k.EMA(20, "close")) },
+		"HMA":                func() (invalidChecker, error) { return wrap(k.HMA(20, "close")) },
+		"JingZheMA":          func() (invalidChecker, error) { return wrap(k.JingZheMA(10, 20)) },
+		"KDJ":                func() (invalidChecker, error) { return wrap(k.KDJ(9, 3, 3)) },
+		"Keltner":            func() (invalidChecker, error) { return wrap(k.Keltner(20, 10, 2)) },
+		"MACD":               func() (invalidChecker, error) { return wrap(k.MACD("close", 12, 26, 9)) },
+		"OBV":                func() (invalidChecker, error) { return wrap(k.OBV()) },
+		"RMA":                func() (invalidChecker, error) { return wrap(k.RMA(14, "close")) },
+		"RSI":                func() (invalidChecker, error) { return wrap(k.RSI(14, "close")) },
+		"RSI SMA":            func() (invalidChecker, error) { return wrap(k.RSI(14, "close", RSISMA)) },
+		"SMA":                func() (invalidChecker, error) { return wrap(k.SMA(20, "close")) },
+		"Squeeze":            func() (invalidChecker, error) { return wrap(k.Squeeze(20, 2, 20, 1.5)) },
+		"StochRSI":           func() (invalidChecker, error) { return wrap(k.StochRSI(14, 14, 3, 3, "close")) },
+		"SuperTrend":         func() (invalidChecker, error) { return wrap(k.SuperTrend(10, 3)) },
+		"SuperTrendPivot":    func() (invalidChecker, error) { return wrap(k.SuperTrendPivot(2, 3, 10)) },
+		"SuperTrendPivotHl2": func() (invalidChecker, error) { return wrap(k.SuperTrendPivotHl2(10, 3)) },
+		"T3":                 func() (invalidChecker, error) { return wrap(k.T3(5, 0.7, "close")) },
+		"TRIX":               func() (invalidChecker, error) { return wrap(k.TRIX(15, "close")) },
+		"VolatilityRatio":    func() (invalidChecker, error) { return wrap(CalculateVolatilityRatio(k, 10, 50)) },
+		"VolumeProfile":      func() (invalidChecker, error) { return wrap(k.VolumeProfile(20)) },
+		"WilliamsR":          func() (invalidChecker, error) { return wrap(k.WilliamsR(14)) },
+		"WMA":                func() (invalidChecker, error) { return wrap(k.WMA(20, "close")) },
+	}
+}
+
+func TestConstantPriceSweep(t *testing.T) {
+	scenarios := map[string]KlineDatas{
+		"constant price":             constantKlines(300, 100, 10),
+		"constant price zero volume": constantKlines(300, 100, 0),
+		"zero price":                 constantKlines(300, 0, 0),
+	}
+	for scenario, klines := range scenarios {
+		for name, calc := range indicatorSweep(klines) {
+			result, err := calc()
+			if err != nil {
+				t.Errorf("%s/%s: %v", scenario, name, err)
+				continue
+			}
+			if result.ContainsInvalid() {
+				t.Errorf("%s/%s: result contains NaN or Inf", scenario, name)
+			}
+		}
+	}
+
+	trades := make([]Trade, 100)
+	for i := range trades {
+		trades[i] = Trade{Price: 100, BuyerIsMaker: i%2 == 0, Time: int64(i)}
+	}
+	cvd, err := CalculateCVD(trades)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cvd.ContainsInvalid() {
+		t.Error("CVD: result contains NaN or Inf")
+	}
+}
+
+func TestSafeDivideAndContainsInvalid(t *testing.T) {
+	if got := safeDivide(1, 0, 50); got != 50 {
+		t.Errorf("safeDivide(1, 0, 50) = %v, want 50", got)
+	}
+	if got := safeDivide(1, 4, 50); got != 0.25 {
+		t.Errorf("safeDivide(1, 4, 50) = %v, want 0.25", got)
+	}
+	if containsInvalid([]float64{1, 2}, nil) {
+		t.Error("finite values reported invalid")
+	}
+	if !containsInvalid([]float64{1}, []float64{math.Inf(-1)}) {
+		t.Error("Inf not detected")
+	}
+	if !containsInvalid([]float64{math.NaN()}) {
+		t.Error("NaN not detected")
+	}
+}
@@ -0,0 +1,126 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// TaTRIX 表示三重指数平滑平均线(TRIX)的计算结果
+// 说明：
+//
+//	TRIX对价格做三次EMA平滑，再计算1周期变化率：
+//	TRIX = (EMA3[i] - EMA3[i-1]) / EMA3[i-1] * 100
+//	特点：
+//	- 三重平滑过滤了大部分短期噪音
+//	- 围绕0轴波动，大于0表示上涨趋势，小于0表示下跌趋势
+//	- 常配合其信号线（TRIX的均线）判断买卖点
+type TaTRIX struct {
+	Values    []float64 `json:"values"`     // TRIX值序列（百分比）
+	Period    int       `json:"period"`     // EMA平滑周期
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
+}
+
+// CalculateTRIX 计算三重指数平滑平均线
+// 说明：
+//
+//	计算步骤：
+//	1. 与T3相同的方式串联计算三重EMA，三条EMA均以首个价格为初始值
+//	2. TRIX = (EMA3[i] - EMA3[i-1]) / EMA3[i-1] * 100
+//	三重EMA需要约3倍周期收敛，索引period*3之前为预热区
+//
+// 参数：
+//   - prices: 价格序列
+//   - period: EMA平滑周期，通常为12-15
+//
+// 返回值：
+//   - *TaTRIX: 包含TRIX计算结果的结构体指针
+//   - error: 计算过程中的错误，如数据不足等
+//
+// 示例：
+//
+//	trix, err := CalculateTRIX(prices, 15)
+func CalculateTRIX(prices []float64, period int) (*TaTRIX, error) {
+	length := len(prices)
+	if period <= 0 || length <= period*3 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	slices := preallocateSlices(length, 4)
+	ema1, ema2, ema3, trix := slices[0], slices[1], slices[2], slices[3]
+
+	k := 2.0 / float64(period+1)
+	ema1[0], ema2[0], ema3[0] = prices[0], prices[0], prices[0]
+	for i := 1; i < length; i++ {
+		ema1[i] = prices[i]*k + ema1[i-1]*(1-k)
+		ema2[i] = ema1[i]*k + ema2[i-1]*(1-k)
+		ema3[i] = ema2[i]*k + ema3[i-1]*(1-k)
+	}
+
+	validFrom := period * 3
+	for i := validFrom; i < length; i++ {
+		trix[i] = safeDivide(ema3[i]-ema3[i-1], ema3[i-1], 0) * 100
+	}
+
+	return &TaTRIX{
+		Values:    trix,
+		Period:    period,
+		ValidFrom: validFrom,
+	}, nil
+}
+
+// TRIX 为K线数据计算三重指数平滑平均线
+// 参数：
+//   - period: EMA平滑周期
+//   - source: 价格类型，支持"open"、"high"、"low"、"close"
+//
+// 返回值：
+//   - *TaTRIX: 包含TRIX计算结果的结构体指针
+//   - error: 计算过程中的错误
+func (k *KlineDatas) TRIX(period int, source string) (*TaTRIX, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateTRIX(prices, period)
+}
+
+// TRIX_ 获取最新的TRIX值
+// 参数：
+//   - period: EMA平滑周期
+//   - source: 价格类型
+//
+// 返回值：
+//   - float64: 最新的TRIX值
+func (k *KlineDatas) TRIX_(period int, source string) float64 {
+	trix, err := k.TRIX(period, source)
+	if err != nil {
+		return 0
+	}
+	return trix.Value()
+}
+
+// Value 获取最新的TRIX值
+// 说明：
+//
+//	返回TRIX的最新值
+//	使用建议：
+//	- 上穿0轴视为趋势转多，下穿0轴视为趋势转空
+//	- 与价格出现背离时可能预示趋势反转
+//
+// 返回值：
+//   - float64: 最新的TRIX值
+func (t *TaTRIX) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaTRIX) ContainsInvalid() bool {
+	return containsInvalid(t.Values)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
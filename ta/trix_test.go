@@ -0,0 +1,35 @@
+package ta
+
+import "testing"
+
+func TestCalculateTRIXReference(t *testing.T) {
+	// 以首个价格为种子的三重EMA的1周期变化率，按定义独立计算，保留4位小数
+	want := []float64{
+		0.2726, 0.2236, 0.2083, 0.1838, 0.1093, 0.0813, 0.0746, 0.0296, 0.0435,
+		0.0156, -0.0525, -0.2193, -0.3526, -0.4260, -0.4190, -0.4684, -0.5677, -0.5981,
+	}
+	trix, err := CalculateTRIX(stockChartsRSICloses, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trix.ValidFrom != 15 {
+		t.Fatalf("ValidFrom = %d, want 15", trix.ValidFrom)
+	}
+	assertSeries(t, "TRIX", trix.Values, 15, want, 1e-4)
+	if got := trix.Value(); got < -0.5982 || got > -0.5980 {
+		t.Errorf("Value() = %v, want -0.5981", got)
+	}
+}
+
+func TestCalculateTRIXPeriodOne(t *testing.T) {
+	// 周期为1时三重EMA即价格本身，TRIX等于价格的百分比变化
+	trix, err := CalculateTRIX([]float64{100, 110, 121, 133.1, 119.79}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSeries(t, "TRIX", trix.Values, 3, []float64{10, -10}, 1e-9)
+
+	if _, err := CalculateTRIX(make([]float64, 15), 5); err == nil {
+		t.Error("expected error for insufficient data")
+	}
+}
@@ -0,0 +1,216 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaVolumeProfile 表示成交量分布(Volume Profile)的计算结果
+// 说明：
+//
+//	将窗口内的成交量按价格区间统计，得到价格-成交量直方图：
+//	1. 把窗口的最高价与最低价之间等分为bins个价格区间
+//	2. 每根K线的成交量按其高低价区间与各价格区间的重叠长度均匀分配
+//	3. POC（控制点）为成交量最大的价格区间
+//	4. 价值区为包含指定比例成交量的价格范围，通常取70%
+//	特点：
+//	- 成交密集区通常构成支撑与阻力
+//	- 价格回到POC附近时常出现震荡
+//	- 与均线类指标不同，反映的是价格维度而非时间维度的信息
+type TaVolumeProfile struct {
+	Prices  []float64 `json:"prices"`   // 各价格区间的中间价
+	Volumes []float64 `json:"volumes"`  // 各价格区间的成交量
+	Low     float64   `json:"low"`      // 窗口最低价，即第一个区间的下沿
+	High    float64   `json:"high"`     // 窗口最高价，即最后一个区间的上沿
+	BinSize float64   `json:"bin_size"` // 单个价格区间的宽度
+	Bins    int       `json:"bins"`     // 价格区间数量
+}
+
+// CalculateVolumeProfile 计算成交量分布
+// 说明：
+//
+//	最高价等于最低价的K线，成交量全部计入其价格所在的区间
+//	窗口内价格完全不变时，全部成交量计入第一个区间
+//
+// 参数：
+//   - klineData: K线数据，通常先用 Keep 截取需要统计的窗口
+//   - bins: 价格区间数量
+//
+// 返回值：
+//   - *TaVolumeProfile: 包含成交量分布计算结果的结构体指针
+//   - error: 计算过程中的错误，如数据不足等
+//
+// 示例：
+//
+//	vp, err := CalculateVolumeProfile(klineData, 50)
+//	poc := vp.POC()
+//	low, high := vp.ValueArea(0.7)
+func CalculateVolumeProfile(klineData KlineDatas, bins int) (*TaVolumeProfile, error) {
+	if bins <= 0 {
+		return nil, fmt.Errorf("价格区间数量必须大于0")
+	}
+	if len(klineData) == 0 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	low, high := klineData[0].Low, klineData[0].High
+	for _, kline := range klineData[1:] {
+		low = math.Min(low, kline.Low)
+		high = math.Max(high, kline.High)
+	}
+
+	binSize := (high - low) / float64(bins)
+	prices := make([]float64, bins)
+	volumes := make([]float64, bins)
+	for i := range prices {
+		prices[i] = low + binSize*(float64(i)+0.5)
+	}
+
+	binIndex := func(price float64) int {
+		if binSize == 0 {
+			return 0
+		}
+		idx := int((price - low) / binSize)
+		if idx >= bins {
+			idx = bins - 1
+		}
+		return idx
+	}
+
+	for _, kline := range klineData {
+		span := kline.High - kline.Low
+		first, last := binIndex(kline.Low), binIndex(kline.High)
+		if span <= 0 || first == last {
+			volumes[first] += kline.Volume
+			continue
+		}
+		for j := first; j <= last; j++ {
+			bottom := low + binSize*float64(j)
+			overlap := math.Min(kline.High, bottom+binSize) - math.Max(kline.Low, bottom)
+			if overlap > 0 {
+				volumes[j] += kline.Volume * overlap / span
+			}
+		}
+	}
+
+	return &TaVolumeProfile{
+		Prices:  prices,
+		Volumes: volumes,
+		Low:     low,
+		High:    high,
+		BinSize: binSize,
+		Bins:    bins,
+	}, nil
+}
+
+// VolumeProfile 为K线数据计算成交量分布
+// 参数：
+//   - bins: 价格区间数量
+//
+// 返回值：
+//   - *TaVolumeProfile: 包含成交量分布计算结果的结构体指针
+//   - error: 计算过程中的错误
+func (k *KlineDatas) VolumeProfile(bins int) (*TaVolumeProfile, error) {
+	return CalculateVolumeProfile(*k, bins)
+}
+
+// VolumeProfile_ 获取成交量分布的POC价格
+// 参数：
+//   - bins: 价格区间数量
+//
+// 返回值：
+//   - float64: POC价格
+func (k *KlineDatas) VolumeProfile_(bins int) float64 {
+	vp, err := k.VolumeProfile(bins)
+	if err != nil {
+		return 0
+	}
+	return vp.Value()
+}
+
+// POC 获取控制点(Point of Control)价格
+// 说明：
+//
+//	返回成交量最大的价格区间的中间价，成交量相同时取价格较低的区间
+//
+// 返回值：
+//   - float64: POC价格
+func (t *TaVolumeProfile) POC() float64 {
+	return t.Prices[t.pocIndex()]
+}
+
+// pocIndex 返回成交量最大的价格区间索引
+func (t *TaVolumeProfile) pocIndex() int {
+	idx := 0
+	for i, v := range t.Volumes {
+		if v > t.Volumes[idx] {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// ValueArea 获取价值区(Value Area)的价格范围
+// 说明：
+//
+//	从POC开始，每次向成交量较大的相邻一侧扩展一个区间，直到累计成交量达到总量的pct
+//	两侧成交量相同时优先向上扩展
+//
+// 参数：
+//   - pct: 成交量占比，取值范围(0, 1]，常用0.7；超出范围时按0.7处理
+//
+// 返回值：
+//   - low: 价值区下沿价格
+//   - high: 价值区上沿价格
+func (t *TaVolumeProfile) ValueArea(pct float64) (low, high float64) {
+	if pct <= 0 || pct > 1 {
+		pct = 0.7
+	}
+
+	var total float64
+	for _, v := range t.Volumes {
+		total += v
+	}
+
+	poc := t.pocIndex()
+	lo, hi := poc, poc
+	accumulated := t.Volumes[poc]
+	for accumulated < total*pct && (lo > 0 || hi < t.Bins-1) {
+		var below, above float64 = -1, -1
+		if lo > 0 {
+			below = t.Volumes[lo-1]
+		}
+		if hi < t.Bins-1 {
+			above = t.Volumes[hi+1]
+		}
+		if above >= below {
+			hi++
+			accumulated += above
+		} else {
+			lo--
+			accumulated += below
+		}
+	}
+
+	return t.Low + t.BinSize*float64(lo), t.Low + t.BinSize*float64(hi+1)
+}
+
+// Value 获取POC价格
+// 返回值：
+//   - float64: POC价格
+func (t *TaVolumeProfile) Value() float64 {
+	return t.POC()
+}
+
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaVolumeProfile) ContainsInvalid() bool {
+	return containsInvalid(t.Prices, t.Volumes)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -17,8 +17,9 @@ import (
 //	- VR < 1 表示短期波动低于长期波动，市场活跃度降低
 //	- VR 的突变通常预示着市场即将发生重要变化
 type TaVolatilityRatio struct {
-	Values []float64 `json:"values"` // 波动率比率的时间序列
-	Period int       `json:"period"` // 计算使用的长周期
+	Values    []float64 `json:"values"`     // 波动率比率的时间序列
+	Period    int       `json:"period"`     // 计算使用的长周期
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
 }
 
 // CalculateVolatilityRatio 计算波动率比率指标
@@ -79,16 +80,13 @@ func CalculateVolatilityRatio(klineData KlineDatas, shortPeriod, longPeriod int)
 		}
 		longTR /= float64(longPeriod)
 
-		if longTR != 0 {
-			ratio[i] = shortTR / longTR
-		} else {
-			ratio[i] = 1.0
-		}
+		ratio[i] = safeDivide(shortTR, longTR, 1.0)
 	}
 
 	return &TaVolatilityRatio{
-		Values: ratio,
-		Period: longPeriod,
+		Values:    ratio,
+		Period:    longPeriod,
+		ValidFrom: longPeriod,
 	}, nil
 }
 
@@ -141,6 +139,118 @@ func (vr *TaVolatilityRatio) Value() float64 {
 	return vr.Values[len(vr.Values)-1]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (vr *TaVolatilityRatio) ContainsInvalid() bool {
+	return containsInvalid(vr.Values)
+}
+
+// 市场状态
+const (
+	RegimeTrending     = "trending"     // 趋势行情
+	RegimeRanging      = "ranging"      // 震荡行情
+	RegimeTransitional = "transitional" // 过渡状态，趋势与震荡特征都不明显
+)
+
+// 市场状态判断的默认阈值
+const (
+	regimeVRExpansion   = 1.2 // VR高于该值视为波动扩张
+	regimeVRContraction = 0.8 // VR低于该值视为波动收缩
+	regimeADXTrending   = 25  // ADX高于该值视为趋势行情
+	regimeADXRanging    = 20  // ADX低于该值视为缺乏趋势
+)
+
+// Regime 根据最新的VR值判断市场状态
+// 说明：
+//
+//   - VR >= expansion: 短期波动显著扩大，返回 RegimeTrending
+//   - VR <= contraction: 短期波动显著收缩，返回 RegimeRanging
+//   - 其他情况返回 RegimeTransitional
+//
+// 参数：
+//   - thresholds: 可选的阈值，依次为 expansion（默认1.2）和 contraction（默认0.8）
+//
+// 返回值：
+//   - string: 市场状态，没有数据时返回 RegimeTransitional
+//
+// 示例：
+//
+//	regime := vr.Regime()          // 使用默认阈值
+//	regime = vr.Regime(1.5, 0.7)   // 自定义阈值
+func (vr *TaVolatilityRatio) Regime(thresholds ...float64) string {
+	expansion, contraction := regimeVRExpansion, regimeVRContraction
+	if len(thresholds) > 0 {
+		expansion = thresholds[0]
+	}
+	if len(thresholds) > 1 {
+		contraction = thresholds[1]
+	}
+
+	if len(vr.Values) == 0 || len(vr.Values)-1 < vr.ValidFrom {
+		return RegimeTransitional
+	}
+	value := vr.Value()
+	switch {
+	case value >= expansion:
+		return RegimeTrending
+	case value <= contraction:
+		return RegimeRanging
+	default:
+		return RegimeTransitional
+	}
+}
+
+// MarketRegime 结合ADX与VR判断当前市场状态
+// 说明：
+//
+//	判断规则：
+//	1. ADX > 25 时为趋势行情
+//	2. ADX < 20 且 VR 显示波动收缩时为震荡行情
+//	3. 其他情况为过渡状态
+//	数据不足 adxPeriod*2+1 根、ADX尚未平滑完成时返回过渡状态
+//	用于在趋势跟踪与均值回归策略之间切换
+//
+// 参数：
+//   - adxPeriod: ADX计算周期，通常为14
+//   - shortPeriod: VR短周期，通常为5
+//   - longPeriod: VR长周期，通常为14
+//
+// 返回值：
+//   - string: RegimeTrending、RegimeRanging 或 RegimeTransitional
+//   - error: 计算过程中的错误，如数据不足等
+//
+// 示例：
+//
+//	regime, err := klines.MarketRegime(14, 5, 14)
+//	if regime == ta.RegimeTrending {
+//		// 启用趋势跟踪逻辑
+//	}
+func (k *KlineDatas) MarketRegime(adxPeriod, shortPeriod, longPeriod int) (string, error) {
+	adx, err := k.ADX(adxPeriod)
+	if err != nil {
+		return "", err
+	}
+	vr, err := k.VolatilityRatio(shortPeriod, longPeriod)
+	if err != nil {
+		return "", err
+	}
+
+	last := len(adx.ADX) - 1
+	if last < adx.ValidFrom {
+		return RegimeTransitional, nil
+	}
+	adxValue := adx.ADX[last]
+	switch {
+	case adxValue > regimeADXTrending:
+		return RegimeTrending, nil
+	case adxValue < regimeADXRanging && vr.Regime() == RegimeRanging:
+		return RegimeRanging, nil
+	default:
+		return RegimeTransitional, nil
+	}
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
@@ -0,0 +1,44 @@
+package ta
+
+import "testing"
+
+// trendKlines 生成单边上涨的K线，+DM持续为正、-DM为0，DX恒为100
+func trendKlines(n int) KlineDatas {
+	klines := make(KlineDatas, n)
+	for i := range klines {
+		base := 100 + float64(i)
+		klines[i] = &KlineData{StartTime: int64(i) * 60000, Open: base, High: base + 1, Low: base - 1, Close: base + 0.5, Volume: 1}
+	}
+	return klines
+}
+
+func TestMarketRegimeBeforeADXValid(t *testing.T) {
+	const adxPeriod = 14
+	// 20根K线足以计算ADX与VR，但最后一根仍在 ValidFrom(28) 之前，只有未平滑的DX
+	klines := trendKlines(20)
+
+	adx, err := klines.ADX(adxPeriod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := adx.ADX[len(adx.ADX)-1]; last <= regimeADXTrending {
+		t.Fatalf("raw DX = %v, want > %v so the guard is exercised", last, regimeADXTrending)
+	}
+
+	regime, err := klines.MarketRegime(adxPeriod, 5, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if regime != RegimeTransitional {
+		t.Fatalf("MarketRegime = %q, want %q", regime, RegimeTransitional)
+	}
+
+	full := trendKlines(60)
+	regime, err = full.MarketRegime(adxPeriod, 5, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if regime != RegimeTrending {
+		t.Fatalf("MarketRegime on full data = %q, want %q", regime, RegimeTrending)
+	}
+}
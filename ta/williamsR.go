@@ -17,8 +17,9 @@ import (
 //	- 指标与价格的背离可能预示趋势反转
 //	- 相比其他超买超卖指标反应更快速
 type TaWilliamsR struct {
-	Values []float64 `json:"values"` // Williams %R值的时间序列
-	Period int       `json:"period"` // 计算周期
+	Values    []float64 `json:"values"`     // Williams %R值的时间序列
+	Period    int       `json:"period"`     // 计算周期
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
 }
 
 // CalculateWilliamsR 计算威廉指标
@@ -79,8 +80,9 @@ func CalculateWilliamsR(high, low, close []float64, period int) (*TaWilliamsR, e
 	}
 
 	return &TaWilliamsR{
-		Values: wr,
-		Period: period,
+		Values:    wr,
+		Period:    period,
+		ValidFrom: period - 1,
 	}, nil
 }
 
@@ -151,6 +153,13 @@ func (t *TaWilliamsR) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaWilliamsR) ContainsInvalid() bool {
+	return containsInvalid(t.Values)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
@@ -0,0 +1,236 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaWMA 表示加权移动平均线(Weighted Moving Average)的计算结果
+// 说明：
+//
+//	WMA对周期内的价格按线性递减的权重加权平均：
+//	最新价格权重为period，最早价格权重为1
+//	特点：
+//	- 相比SMA更重视近期价格
+//	- 滞后性小于SMA
+//	- 是HMA等指标的计算基础
+type TaWMA struct {
+	Values    []float64 `json:"values"`     // WMA值序列
+	Period    int       `json:"period"`     // 计算周期
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
+}
+
+// CalculateWMA 计算加权移动平均线
+// 说明：
+//
+//	WMA = (P1*1 + P2*2 + ... + Pn*n) / (1 + 2 + ... + n)，Pn为最新价格
+//	使用滑动窗口维护加权和与普通和，每个位置的计算为O(1)
+//
+// 参数：
+//   - prices: 价格序列
+//   - period: 计算周期
+//
+// 返回值：
+//   - *TaWMA: 包含WMA计算结果的结构体指针
+//   - error: 计算过程中的错误，如数据不足等
+//
+// 示例：
+//
+//	wma, err := CalculateWMA(prices, 20)
+func CalculateWMA(prices []float64, period int) (*TaWMA, error) {
+	if period <= 0 || len(prices) < period {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	return &TaWMA{
+		Values:    wmaFrom(prices, period, 0),
+		Period:    period,
+		ValidFrom: period - 1,
+	}, nil
+}
+
+// wmaFrom 从start位置开始计算WMA，start之前的数据视为预热区不参与计算
+// 首个有效值位于 start+period-1，调用方需保证数据长度足够
+func wmaFrom(prices []float64, period, start int) []float64 {
+	length := len(prices)
+	result := make([]float64, length)
+
+	denominator := float64(period*(period+1)) / 2
+	var weighted, sum float64
+	for i := 0; i < period; i++ {
+		weighted += prices[start+i] * float64(i+1)
+		sum += prices[start+i]
+	}
+	first := start + period - 1
+	result[first] = weighted / denominator
+
+	for i := first + 1; i < length; i++ {
+		// 窗口右移：所有权重减1（减去旧窗口的和），新价格权重为period
+		weighted += prices[i]*float64(period) - sum
+		sum += prices[i] - prices[i-period]
+		result[i] = weighted / denominator
+	}
+	return result
+}
+
+// WMA 为K线数据计算加权移动平均线
+// 参数：
+//   - period: 计算周期
+//   - source: 价格类型，支持"open"、"high"、"low"、"close"
+//
+// 返回值：
+//   - *TaWMA: 包含WMA计算结果的结构体指针
+//   - error: 计算过程中的错误
+func (k *KlineDatas) WMA(period int, source string) (*TaWMA, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateWMA(prices, period)
+}
+
+// WMA_ 获取最新的WMA值
+// 参数：
+//   - period: 计算周期
+//   - source: 价格类型
+//
+// 返回值：
+//   - float64: 最新的WMA值
+func (k *KlineDatas) WMA_(period int, source string) float64 {
+	wma, err := k.WMA(period, source)
+	if err != nil {
+		return 0
+	}
+	return wma.Value()
+}
+
+// Value 获取最新的WMA值
+// 返回值：
+//   - float64: 最新的WMA值
+func (t *TaWMA) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaWMA) ContainsInvalid() bool {
+	return containsInvalid(t.Values)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+
+// TaHMA 表示赫尔移动平均线(Hull Moving Average)的计算结果
+// 说明：
+//
+//	HMA由Alan Hull提出，通过WMA的差值抵消滞后：
+//	HMA = WMA(2*WMA(n/2) - WMA(n), sqrt(n))
+//	特点：
+//	- 滞后明显小于SMA、EMA
+//	- 曲线依然平滑
+//	- 适合需要快速响应的趋势跟踪
+type TaHMA struct {
+	Values    []float64 `json:"values"`     // HMA值序列
+	Period    int       `json:"period"`     // 计算周期
+	ValidFrom int       `json:"valid_from"` // 首个有效值的索引，之前为预热区（值为0）
+}
+
+// CalculateHMA 计算赫尔移动平均线
+// 说明：
+//
+//	计算步骤：
+//	1. 计算 WMA(n/2) 与 WMA(n)
+//	2. 得到原始序列 raw = 2*WMA(n/2) - WMA(n)，从索引n-1开始有效
+//	3. 对raw的有效部分计算 WMA(floor(sqrt(n)))
+//	首个有效值位于 n-1 + floor(sqrt(n)) - 1
+//
+// 参数：
+//   - prices: 价格序列
+//   - period: 计算周期，至少为2
+//
+// 返回值：
+//   - *TaHMA: 包含HMA计算结果的结构体指针
+//   - error: 计算过程中的错误，如数据不足等
+//
+// 示例：
+//
+//	hma, err := CalculateHMA(prices, 9)
+func CalculateHMA(prices []float64, period int) (*TaHMA, error) {
+	if period < 2 {
+		return nil, fmt.Errorf("HMA周期至少为2")
+	}
+	sqrtPeriod := int(math.Floor(math.Sqrt(float64(period))))
+	validFrom := period - 1 + sqrtPeriod - 1
+	if len(prices) <= validFrom {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	half := wmaFrom(prices, period/2, 0)
+	full := wmaFrom(prices, period, 0)
+
+	raw := make([]float64, len(prices))
+	for i := period - 1; i < len(prices); i++ {
+		raw[i] = 2*half[i] - full[i]
+	}
+
+	return &TaHMA{
+		Values:    wmaFrom(raw, sqrtPeriod, period-1),
+		Period:    period,
+		ValidFrom: validFrom,
+	}, nil
+}
+
+// HMA 为K线数据计算赫尔移动平均线
+// 参数：
+//   - period: 计算周期
+//   - source: 价格类型，支持"open"、"high"、"low"、"close"
+//
+// 返回值：
+//   - *TaHMA: 包含HMA计算结果的结构体指针
+//   - error: 计算过程中的错误
+func (k *KlineDatas) HMA(period int, source string) (*TaHMA, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateHMA(prices, period)
+}
+
+// HMA_ 获取最新的HMA值
+// 参数：
+//   - period: 计算周期
+//   - source: 价格类型
+//
+// 返回值：
+//   - float64: 最新的HMA值
+func (k *KlineDatas) HMA_(period int, source string) float64 {
+	hma, err := k.HMA(period, source)
+	if err != nil {
+		return 0
+	}
+	return hma.Value()
+}
+
+// Value 获取最新的HMA值
+// 返回值：
+//   - float64: 最新的HMA值
+func (t *TaHMA) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// ContainsInvalid 检查结果中是否包含NaN或Inf
+// 返回值：
+//   - bool: 任一结果序列包含NaN或Inf时返回true
+func (t *TaHMA) ContainsInvalid() bool {
+	return containsInvalid(t.Values)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// TWAPSpec TWAP 拆单参数
+type TWAPSpec struct {
+	Symbol   string        // 交易对，原样传给下单函数
+	Side     string        // 方向，原样传给下单函数
+	TotalQty float64       // 总数量
+	Slices   int           // 拆分的子单数量
+	Interval time.Duration // 相邻子单的下单间隔
+	StepSize float64       // 可选；数量步长，大于0时每个子单向下取整到步长，余量并入最后一单
+}
+
+// TWAPPlaceFunc 下单函数，由交易所客户端实现，返回实际成交数量
+// 限频等待应在该函数内完成
+type TWAPPlaceFunc func(ctx context.Context, symbol, side string, qty float64) (filled float64, err error)
+
+// TWAPSlice 单个子单的执行结果
+type TWAPSlice struct {
+	Index  int       // 子单序号，从0开始
+	Qty    float64   // 下单数量
+	Filled float64   // 成交数量
+	Time   time.Time // 下单时间
+	Err    error     // 下单错误
+}
+
+// TWAPResult TWAP 执行结果
+type TWAPResult struct {
+	Slices []TWAPSlice // 已执行的子单
+	Filled float64     // 累计成交数量
+}
+
+// ExecuteTWAP 将 TotalQty 均分为 Slices 个子单，每隔 Interval 调用一次 place
+// 第一个子单立即下单；单个子单失败会记录在结果中并继续执行后续子单
+// ctx 取消时停止，返回已执行部分的结果和 ctx 的错误
+func ExecuteTWAP(ctx context.Context, spec TWAPSpec, place TWAPPlaceFunc) (TWAPResult, error) {
+	var result TWAPResult
+	if place == nil {
+		return result, fmt.Errorf("place func must not be nil")
+	}
+	if spec.TotalQty <= 0 || spec.Slices <= 0 {
+		return result, fmt.Errorf("total qty and slices must be positive")
+	}
+	if spec.Slices > 1 && spec.Interval <= 0 {
+		return result, fmt.Errorf("interval must be positive")
+	}
+
+	qtys := twapQuantities(spec)
+	result.Slices = make([]TWAPSlice, 0, len(qtys))
+	for i, qty := range qtys {
+		if i > 0 {
+			timer := time.NewTimer(spec.Interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return result, ctx.Err()
+			case <-timer.C:
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		slice := TWAPSlice{Index: i, Qty: qty, Time: time.Now()}
+		if qty > 0 {
+			slice.Filled, slice.Err = place(ctx, spec.Symbol, spec.Side, qty)
+		}
+		result.Slices = append(result.Slices, slice)
+		result.Filled += slice.Filled
+	}
+	return result, nil
+}
+
+// twapQuantities 计算各子单数量，按步长取整时余量并入最后一单
+func twapQuantities(spec TWAPSpec) []float64 {
+	qtys := make([]float64, spec.Slices)
+	each := RoundToTick(spec.TotalQty/float64(spec.Slices), spec.StepSize, RoundFloor)
+	rest := spec.TotalQty
+	for i := range qtys[:len(qtys)-1] {
+		qtys[i] = each
+		rest -= each
+	}
+	qtys[len(qtys)-1] = math.Max(RoundToTick(rest, spec.StepSize, RoundFloor), 0)
+	return qtys
+}
@@ -75,17 +75,17 @@ type Client struct {
 type ClientConfig struct {
 	BaseURL          string         // 例如: https://uverif.xxx/api/user
 	AppID            int            // 应用 ID
-	AppKey           string         // 用于 MD5 签名
+	AppKey           Secret         // 用于 MD5 签名
 	Version          string         // 客户端语义版本，例如: "1.0.0"
 	VersionIndex     string         // 例如: "web"
-	ClientPrivateKey string         // PEM 格式的私钥，用于解密 payload
+	ClientPrivateKey Secret         // PEM 格式的私钥，用于解密 payload
 	ServerPublicKey  string         // PEM 格式的公钥，用于加密 payload
 	HTTPTimeout      time.Duration  // 可选；为零时使用默认值
 	EncryptionMode   EncryptionMode // AES/DES/RC4/RSA/none
 	EncodingMode     EncodingMode   // 对称模式的编码方式：base64 或 hex
-	SymmetricKey     string         // AES/DES/RC4 的共享密钥
+	SymmetricKey     Secret         // AES/DES/RC4 的共享密钥
 	DisableSignature bool           // 为 true 时，省略 MD5 签名
-	ProxyURL         string         // 代理 URL 可使用 utils.GetProxy() 获取代理URL
+	ProxyURL         SecretURL      // 代理 URL 可使用 utils.GetProxy() 获取代理URL
 }
 
 // EncryptionMode 枚举支持的 payload 保护策略
@@ -108,6 +108,70 @@ const (
 	EncodingHex    EncodingMode = "hex"
 )
 
+// Secret 密钥类字段的类型，通过 fmt 或 json.Marshal 输出时保留首尾各2个字符，中间以*代替
+// 方法只作用于字段本身，ClientConfig 以具名或匿名方式嵌入其他结构体后输出时同样脱敏，且不影响外层字段
+// 注意：json.Marshal 的结果已脱敏，不能再反序列化回可用的配置；需要原值时使用 string(secret)
+type Secret string
+
+// String 实现 fmt.Stringer，返回脱敏后的值
+func (s Secret) String() string {
+	return maskSecret(string(s))
+}
+
+// GoString 实现 fmt.GoStringer，使 %#v 输出同样脱敏
+func (s Secret) GoString() string {
+	return strconv.Quote(s.String())
+}
+
+// MarshalJSON 序列化为脱敏后的字符串
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// SecretURL 可能包含密码的URL，通过 fmt 或 json.Marshal 输出时隐藏密码，其余部分保持不变
+type SecretURL string
+
+// String 实现 fmt.Stringer，返回隐藏密码后的URL
+func (u SecretURL) String() string {
+	if parsed, err := url.Parse(string(u)); err == nil && u != "" {
+		return parsed.Redacted()
+	}
+	return string(u)
+}
+
+// GoString 实现 fmt.GoStringer，使 %#v 输出同样隐藏密码
+func (u SecretURL) GoString() string {
+	return strconv.Quote(u.String())
+}
+
+// MarshalJSON 序列化为隐藏密码后的URL
+func (u SecretURL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.String())
+}
+
+// Redacted 返回敏感字段已替换为脱敏值的配置副本
+// 直接输出 ClientConfig 已经会脱敏，该方法用于需要把脱敏结果作为普通字符串传递的场景，如按字段写入日志
+// 脱敏后的副本不能用于创建客户端
+func (cfg ClientConfig) Redacted() ClientConfig {
+	cfg.AppKey = Secret(cfg.AppKey.String())
+	cfg.ClientPrivateKey = Secret(cfg.ClientPrivateKey.String())
+	cfg.SymmetricKey = Secret(cfg.SymmetricKey.String())
+	cfg.ProxyURL = SecretURL(cfg.ProxyURL.String())
+	return cfg
+}
+
+// maskSecret 保留首尾各2个字符，中间以*代替；长度不超过6时全部隐藏
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	r := []rune(secret)
+	if len(r) <= 6 {
+		return strings.Repeat("*", len(r))
+	}
+	return string(r[:2]) + strings.Repeat("*", len(r)-4) + string(r[len(r)-2:])
+}
+
 func normalizeConfig(cfg ClientConfig) (ClientConfig, error) {
 	cfg.applyDefaults()
 	if err := cfg.validate(); err != nil {
@@ -185,7 +249,7 @@ func New(cfg ClientConfig) (*Client, error) {
 		return nil, err
 	}
 
-	proxy, err := url.Parse(cfg.ProxyURL)
+	proxy, err := url.Parse(string(cfg.ProxyURL))
 	if err != nil {
 		return nil, err
 	}
@@ -279,7 +343,7 @@ func (c *Client) buildSecurePayload(payload any) (map[string]string, error) {
 	}
 
 	if !c.cfg.DisableSignature {
-		body["sign"] = crypto.MD5Hex(string(jsonBytes) + c.cfg.AppKey)
+		body["sign"] = crypto.MD5Hex(string(jsonBytes) + string(c.cfg.AppKey))
 	}
 	return body, nil
 }
@@ -294,11 +358,11 @@ func (c *Client) encryptPayload(plain []byte) (string, error) {
 		}
 		return crypto.RSAEncrypt(c.cfg.ServerPublicKey, string(plain))
 	case EncryptionAES:
-		return crypto.AesEncrypt(c.cfg.SymmetricKey, string(plain), string(c.cfg.EncodingMode))
+		return crypto.AesEncrypt(string(c.cfg.SymmetricKey), string(plain), string(c.cfg.EncodingMode))
 	case EncryptionDES:
-		return crypto.DesEncrypt(c.cfg.SymmetricKey, string(plain), string(c.cfg.EncodingMode))
+		return crypto.DesEncrypt(string(c.cfg.SymmetricKey), string(plain), string(c.cfg.EncodingMode))
 	case EncryptionRC4:
-		return crypto.Rc4Encrypt(c.cfg.SymmetricKey, string(plain), string(c.cfg.EncodingMode))
+		return crypto.Rc4Encrypt(string(c.cfg.SymmetricKey), string(plain), string(c.cfg.EncodingMode))
 	default:
 		return "", fmt.Errorf("不支持的加密模式 %q", c.cfg.EncryptionMode)
 	}
@@ -315,13 +379,13 @@ func (c *Client) decryptPayload(data string) (string, error) {
 		if c.cfg.ClientPrivateKey == "" {
 			return "", errors.New("解密响应数据需要客户端私钥")
 		}
-		return crypto.RSADecrypt(c.cfg.ClientPrivateKey, data)
+		return crypto.RSADecrypt(string(c.cfg.ClientPrivateKey), data)
 	case EncryptionAES:
-		return crypto.AesDecrypt(c.cfg.SymmetricKey, data, string(c.cfg.EncodingMode))
+		return crypto.AesDecrypt(string(c.cfg.SymmetricKey), data, string(c.cfg.EncodingMode))
 	case EncryptionDES:
-		return crypto.DesDecrypt(c.cfg.SymmetricKey, data, string(c.cfg.EncodingMode))
+		return crypto.DesDecrypt(string(c.cfg.SymmetricKey), data, string(c.cfg.EncodingMode))
 	case EncryptionRC4:
-		return crypto.Rc4Decrypt(c.cfg.SymmetricKey, data, string(c.cfg.EncodingMode))
+		return crypto.Rc4Decrypt(string(c.cfg.SymmetricKey), data, string(c.cfg.EncodingMode))
 	default:
 		return "", fmt.Errorf("不支持的加密模式 %q", c.cfg.EncryptionMode)
 	}
@@ -351,7 +415,7 @@ func (c *Client) verifyResponseSignature(resp APIResponse) error {
 	}
 
 	// 计算签名：MD5(JSON字符串 + AppKey)
-	expectedSign := crypto.MD5Hex(strconv.Itoa(resp.Code) + strconv.FormatInt(resp.Time, 10) + c.cfg.AppKey)
+	expectedSign := crypto.MD5Hex(strconv.Itoa(resp.Code) + strconv.FormatInt(resp.Time, 10) + string(c.cfg.AppKey))
 	// 比较签名（不区分大小写）
 	if !strings.EqualFold(expectedSign, resp.Sign) {
 		return fmt.Errorf("签名验证失败: 期望 %s, 实际 %s", expectedSign, resp.Sign)
@@ -5,6 +5,7 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"errors"
+	"fmt"
 	"io"
 )
 
@@ -46,3 +47,54 @@ func AesDecrypt(key, data, mode string) (string, error) {
 	plain := pkcs7Unpadding(cipherBytes)
 	return string(plain), nil
 }
+
+// ErrAuthFailed 认证解密失败，密文被篡改或密钥错误
+var ErrAuthFailed = errors.New("密文认证失败：数据被篡改或密钥错误")
+
+// AesGcmEncrypt 使用 AES-GCM 进行认证加密，随机 nonce 前置于密文，并根据模式（"base64" 或 "hex"）返回编码结果
+// 密钥长度必须为 16、24 或 32 字节，分别对应 AES-128、AES-192、AES-256
+func AesGcmEncrypt(key, plaintext, encoding string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize(), gcm.NonceSize()+len(plaintext)+gcm.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return encode(encoding, gcm.Seal(nonce, nonce, []byte(plaintext), nil))
+}
+
+// AesGcmDecrypt 对提供的密钥和模式执行 AesGcmEncrypt 的逆操作，认证失败时返回 ErrAuthFailed
+func AesGcmDecrypt(key, data, encoding string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	cipherBytes, err := decode(encoding, data)
+	if err != nil {
+		return "", err
+	}
+	if len(cipherBytes) < gcm.NonceSize()+gcm.Overhead() {
+		return "", errors.New("密文太短")
+	}
+	nonce, cipherBytes := cipherBytes[:gcm.NonceSize()], cipherBytes[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, cipherBytes, nil)
+	if err != nil {
+		return "", ErrAuthFailed
+	}
+	return string(plain), nil
+}
+
+func newGCM(key string) (cipher.AEAD, error) {
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, fmt.Errorf("AES 密钥长度必须为 16/24/32 字节，当前为 %d", len(key))
+	}
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
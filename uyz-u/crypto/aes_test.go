@@ -0,0 +1,68 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAesGcmRoundTrip(t *testing.T) {
+	plain := "会员数据 payload 0123456789"
+	for _, key := range []string{strings.Repeat("k", 16), strings.Repeat("k", 24), strings.Repeat("k", 32)} {
+		for _, encoding := range []string{"base64", "hex"} {
+			enc, err := AesGcmEncrypt(key, plain, encoding)
+			if err != nil {
+				t.Fatalf("key %d %s encrypt: %v", len(key), encoding, err)
+			}
+			got, err := AesGcmDecrypt(key, enc, encoding)
+			if err != nil {
+				t.Fatalf("key %d %s decrypt: %v", len(key), encoding, err)
+			}
+			if got != plain {
+				t.Fatalf("key %d %s round trip = %q, want %q", len(key), encoding, got, plain)
+			}
+		}
+	}
+
+	// 随机 nonce，相同明文两次加密结果不同
+	key := strings.Repeat("k", 16)
+	a, _ := AesGcmEncrypt(key, plain, "hex")
+	b, _ := AesGcmEncrypt(key, plain, "hex")
+	if a == b {
+		t.Fatal("AesGcmEncrypt reused nonce")
+	}
+}
+
+func TestAesGcmTampered(t *testing.T) {
+	key := strings.Repeat("k", 32)
+	enc, err := AesGcmEncrypt(key, "secret", "base64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := base64.StdEncoding.DecodeString(enc)
+	raw[len(raw)-1] ^= 0x01
+	if _, err := AesGcmDecrypt(key, base64.StdEncoding.EncodeToString(raw), "base64"); !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("tampered ciphertext error = %v, want ErrAuthFailed", err)
+	}
+
+	enc, _ = AesGcmEncrypt(key, "secret", "hex")
+	if _, err := AesGcmDecrypt(strings.Repeat("x", 32), enc, "hex"); !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("wrong key error = %v, want ErrAuthFailed", err)
+	}
+}
+
+func TestAesGcmKeyLength(t *testing.T) {
+	key := strings.Repeat("k", 15)
+	if _, err := AesGcmEncrypt(key, "secret", "hex"); err == nil || !strings.Contains(err.Error(), "当前为 15") {
+		t.Fatalf("encrypt with 15-byte key error = %v", err)
+	}
+	valid, _ := AesGcmEncrypt(strings.Repeat("k", 16), "secret", "hex")
+	if _, err := AesGcmDecrypt(key, valid, "hex"); err == nil || !strings.Contains(err.Error(), "当前为 15") {
+		t.Fatalf("decrypt with 15-byte key error = %v", err)
+	}
+	if _, err := AesGcmDecrypt(strings.Repeat("k", 16), hex.EncodeToString(make([]byte, 8)), "hex"); err == nil {
+		t.Fatal("decrypt short ciphertext: want error")
+	}
+}
@@ -0,0 +1,49 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// Ed25519Sign 使用 base64 编码的私钥对消息签名并返回 base64 签名
+// 私钥可以是 32 字节种子或 64 字节完整私钥
+func Ed25519Sign(privateKey, msg string) (string, error) {
+	priv, err := LoadEd25519PrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(msg))), nil
+}
+
+// LoadEd25519PrivateKey 从 base64 编码的密钥（如 Backpack 签发的 API Secret）加载 ed25519 私钥
+// 32 字节时视为种子并派生完整私钥；64 字节时视为完整私钥，并校验其中的公钥与种子是否匹配
+func LoadEd25519PrivateKey(base64Secret string) (ed25519.PrivateKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(base64Secret))
+	if err != nil {
+		return nil, fmt.Errorf("ed25519 私钥不是有效的 base64: %w", err)
+	}
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		priv := ed25519.PrivateKey(raw)
+		derived := ed25519.NewKeyFromSeed(priv.Seed())
+		if !derived.Equal(priv) {
+			return nil, fmt.Errorf("ed25519 私钥中的公钥与种子不匹配")
+		}
+		return priv, nil
+	default:
+		return nil, fmt.Errorf("ed25519 私钥长度必须为 %d 或 %d 字节，当前为 %d", ed25519.SeedSize, ed25519.PrivateKeySize, len(raw))
+	}
+}
+
+// Ed25519PublicKey 从 base64 编码的私钥派生 base64 编码的公钥，可用于核对交易所后台显示的 API Key
+func Ed25519PublicKey(base64Secret string) (string, error) {
+	priv, err := LoadEd25519PrivateKey(base64Secret)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(priv.Public().(ed25519.PublicKey)), nil
+}
@@ -0,0 +1,90 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+)
+
+// RFC 8032 第7.1节 Ed25519 测试向量 TEST 1、2、3
+var rfc8032Vectors = []struct {
+	name   string
+	secret string
+	public string
+	msg    string
+	sig    string
+}{
+	{
+		name:   "TEST 1",
+		secret: "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+		public: "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+		msg:    "",
+		sig: "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e06522490155" +
+			"5fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+	},
+	{
+		name:   "TEST 2",
+		secret: "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+		public: "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+		msg:    "72",
+		sig: "92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da" +
+			"085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00",
+	},
+	{
+		name:   "TEST 3",
+		secret: "c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+		public: "fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025",
+		msg:    "af82",
+		sig: "6291d657deec24024827e69c3abe01a30ce548a284743a445e3680d7db5ac3ac" +
+			"18ff9b538d16f290ae67f760984dc6594a7c15e9716ed28dc027beceea1ec40a",
+	},
+}
+
+func hexToBase64(t *testing.T, s string) string {
+	t.Helper()
+	raw, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func TestEd25519RFC8032(t *testing.T) {
+	for _, v := range rfc8032Vectors {
+		msg, _ := hex.DecodeString(v.msg)
+		wantSig := hexToBase64(t, v.sig)
+		wantPub := hexToBase64(t, v.public)
+
+		// 32字节种子与64字节完整私钥（种子+公钥）都应得到相同结果
+		for _, key := range []string{hexToBase64(t, v.secret), hexToBase64(t, v.secret+v.public)} {
+			sig, err := Ed25519Sign(key, string(msg))
+			if err != nil {
+				t.Fatalf("%s Ed25519Sign: %v", v.name, err)
+			}
+			if sig != wantSig {
+				t.Errorf("%s signature = %s, want %s", v.name, sig, wantSig)
+			}
+			pub, err := Ed25519PublicKey(key)
+			if err != nil {
+				t.Fatalf("%s Ed25519PublicKey: %v", v.name, err)
+			}
+			if pub != wantPub {
+				t.Errorf("%s public key = %s, want %s", v.name, pub, wantPub)
+			}
+		}
+	}
+}
+
+func TestLoadEd25519PrivateKeyInvalid(t *testing.T) {
+	v := rfc8032Vectors[0]
+	mismatched := hexToBase64(t, v.secret+rfc8032Vectors[1].public)
+	for name, key := range map[string]string{
+		"invalid base64":      "not base64!",
+		"wrong length":        base64.StdEncoding.EncodeToString(make([]byte, 16)),
+		"mismatch public key": mismatched,
+	} {
+		if _, err := LoadEd25519PrivateKey(key); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
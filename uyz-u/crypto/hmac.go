@@ -0,0 +1,31 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+)
+
+// HmacSHA256Hex 返回 HMAC-SHA256 的小写 hex 摘要
+func HmacSHA256Hex(key, msg string) string {
+	return hex.EncodeToString(hmacSum(sha256.New, key, msg))
+}
+
+// HmacSHA512Hex 返回 HMAC-SHA512 的小写 hex 摘要
+func HmacSHA512Hex(key, msg string) string {
+	return hex.EncodeToString(hmacSum(sha512.New, key, msg))
+}
+
+// HmacSHA256Base64 返回 HMAC-SHA256 的标准 base64 摘要
+func HmacSHA256Base64(key, msg string) string {
+	return base64.StdEncoding.EncodeToString(hmacSum(sha256.New, key, msg))
+}
+
+func hmacSum(h func() hash.Hash, key, msg string) []byte {
+	mac := hmac.New(h, []byte(key))
+	mac.Write([]byte(msg))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,66 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// RFC 4231 第4节测试用例 1、2、3、6
+var rfc4231Vectors = []struct {
+	name   string
+	key    string
+	msg    string
+	sha256 string
+	sha512 string
+}{
+	{
+		name:   "case 1",
+		key:    strings.Repeat("\x0b", 20),
+		msg:    "Hi There",
+		sha256: "b0344c61d8db38535ca8afceaf0bf12b881dc200c9833da726e9376c2e32cff7",
+		sha512: "87aa7cdea5ef619d4ff0b4241a1d6cb02379f4e2ce4ec2787ad0b30545e17cde" +
+			"daa833b7d6b8a702038b274eaea3f4e4be9d914eeb61f1702e696c203a126854",
+	},
+	{
+		name:   "case 2",
+		key:    "Jefe",
+		msg:    "what do ya want for nothing?",
+		sha256: "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		sha512: "164b7a7bfcf819e2e395fbe73b56e0a387bd64222e831fd610270cd7ea250554" +
+			"9758bf75c05a994a6d034f65f8f0e6fdcaeab1a34d4a6b4b636e070a38bce737",
+	},
+	{
+		name:   "case 3",
+		key:    strings.Repeat("\xaa", 20),
+		msg:    strings.Repeat("\xdd", 50),
+		sha256: "773ea91e36800e46854db8ebd09181a72959098b3ef8c122d9635514ced565fe",
+		sha512: "fa73b0089d56a284efb0f0756c890be9b1b5dbdd8ee81a3655f83e33b2279d39" +
+			"bf3e848279a722c806b485a47e67c807b946a337bee8942674278859e13292fb",
+	},
+	{
+		name:   "case 6",
+		key:    strings.Repeat("\xaa", 131),
+		msg:    "Test Using Larger Than Block-Size Key - Hash Key First",
+		sha256: "60e431591ee0b67f0d8a26aacbf5b77f8e0bc6213728c5140546040f0ee37f54",
+		sha512: "80b24263c7c1a3ebb71493c1dd7be8b49b46d1f41b4aeec1121b013783f8f352" +
+			"6b56d037e05f2598bd0fd2215d6a1e5295e64f73f63f0aec8b915a985d786598",
+	},
+}
+
+func TestHmacRFC4231(t *testing.T) {
+	for _, v := range rfc4231Vectors {
+		if got := HmacSHA256Hex(v.key, v.msg); got != v.sha256 {
+			t.Errorf("%s HmacSHA256Hex = %s, want %s", v.name, got, v.sha256)
+		}
+		if got := HmacSHA512Hex(v.key, v.msg); got != v.sha512 {
+			t.Errorf("%s HmacSHA512Hex = %s, want %s", v.name, got, v.sha512)
+		}
+
+		raw, _ := hex.DecodeString(v.sha256)
+		if got, want := HmacSHA256Base64(v.key, v.msg), base64.StdEncoding.EncodeToString(raw); got != want {
+			t.Errorf("%s HmacSHA256Base64 = %s, want %s", v.name, got, want)
+		}
+	}
+}
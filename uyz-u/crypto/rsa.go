@@ -3,23 +3,20 @@ package crypto
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
 	"errors"
+	"fmt"
 )
 
 // RSAEncrypt 使用提供的 PEM 公钥执行 PKCS#1 v1.5 加密
 func RSAEncrypt(publicKey, data string) (string, error) {
-	block, _ := pem.Decode([]byte(publicKey))
-	if block == nil || block.Type != "PUBLIC KEY" {
-		return "", errors.New("无效的公钥 PEM 块")
-	}
-	keyAny, err := x509.ParsePKIXPublicKey(block.Bytes)
+	pub, err := parsePublicKey(publicKey)
 	if err != nil {
 		return "", err
 	}
-	pub := keyAny.(*rsa.PublicKey)
 
 	chunkSize := pub.Size() - 11
 	plain := []byte(data)
@@ -40,11 +37,7 @@ func RSAEncrypt(publicKey, data string) (string, error) {
 
 // RSADecrypt 使用提供的 PEM 私钥执行 PKCS#1 v1.5 解密
 func RSADecrypt(privateKey, data string) (string, error) {
-	block, _ := pem.Decode([]byte(privateKey))
-	if block == nil || block.Type != "RSA PRIVATE KEY" {
-		return "", errors.New("无效的私钥 PEM 块")
-	}
-	priv, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	priv, err := parsePrivateKey(privateKey)
 	if err != nil {
 		return "", err
 	}
@@ -67,3 +60,83 @@ func RSADecrypt(privateKey, data string) (string, error) {
 	}
 	return string(decrypted), nil
 }
+
+// RSAEncryptOAEP 使用提供的 PEM 公钥执行 OAEP(SHA-256) 加密
+// 明文按模长分块加密，可加密超过单个 RSA 块大小的数据
+func RSAEncryptOAEP(publicKey, data string) (string, error) {
+	pub, err := parsePublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	chunkSize := pub.Size() - 2*hash.Size() - 2
+	if chunkSize <= 0 {
+		return "", fmt.Errorf("RSA 密钥长度 %d 位不足以使用 OAEP(SHA-256) 填充", pub.N.BitLen())
+	}
+
+	plain := []byte(data)
+	var encrypted []byte
+	for i := 0; i < len(plain); i += chunkSize {
+		end := i + chunkSize
+		if end > len(plain) {
+			end = len(plain)
+		}
+		chunk, err := rsa.EncryptOAEP(hash, rand.Reader, pub, plain[i:end], nil)
+		if err != nil {
+			return "", err
+		}
+		encrypted = append(encrypted, chunk...)
+	}
+	return base64.StdEncoding.EncodeToString(encrypted), nil
+}
+
+// RSADecryptOAEP 使用提供的 PEM 私钥执行 OAEP(SHA-256) 解密，是 RSAEncryptOAEP 的逆操作
+func RSADecryptOAEP(privateKey, data string) (string, error) {
+	priv, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	cipherBytes, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", err
+	}
+	chunkSize := priv.Size()
+	if len(cipherBytes)%chunkSize != 0 {
+		return "", errors.New("密文长度与 RSA 密钥长度不匹配")
+	}
+
+	hash := sha256.New()
+	var decrypted []byte
+	for i := 0; i < len(cipherBytes); i += chunkSize {
+		chunk, err := rsa.DecryptOAEP(hash, rand.Reader, priv, cipherBytes[i:i+chunkSize], nil)
+		if err != nil {
+			return "", err
+		}
+		decrypted = append(decrypted, chunk...)
+	}
+	return string(decrypted), nil
+}
+
+func parsePublicKey(publicKey string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKey))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("无效的公钥 PEM 块")
+	}
+	keyAny, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := keyAny.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("公钥不是 RSA 类型")
+	}
+	return pub, nil
+}
+
+func parsePrivateKey(privateKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil || block.Type != "RSA PRIVATE KEY" {
+		return nil, errors.New("无效的私钥 PEM 块")
+	}
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
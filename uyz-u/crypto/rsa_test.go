@@ -0,0 +1,56 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+)
+
+// testRSAKeys 生成 2048 位密钥对，返回 PKIX 公钥与 PKCS#1 私钥的 PEM
+func testRSAKeys(t *testing.T) (pub, priv string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}))
+	priv = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	return pub, priv
+}
+
+func TestRSAOAEPRoundTrip(t *testing.T) {
+	pub, priv := testRSAKeys(t)
+
+	// 2048 位 + SHA-256 单块最多 190 字节，5000 字节需要分 27 块
+	plain := strings.Repeat("multi-chunk payload 分块明文 ", 200)[:5000]
+	enc, err := RSAEncryptOAEP(pub, plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := base64.StdEncoding.DecodeString(enc)
+	if want := (len(plain) + 189) / 190 * 256; len(raw) != want {
+		t.Fatalf("ciphertext length = %d, want %d", len(raw), want)
+	}
+
+	got, err := RSADecryptOAEP(priv, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != plain {
+		t.Fatal("RSA OAEP round trip mismatch")
+	}
+
+	// 密文截掉一个字节后长度不再是模长的整数倍
+	truncated := base64.StdEncoding.EncodeToString(raw[:len(raw)-1])
+	if _, err := RSADecryptOAEP(priv, truncated); err == nil || err.Error() != "密文长度与 RSA 密钥长度不匹配" {
+		t.Fatalf("truncated ciphertext error = %v", err)
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"context"
 	"errors"
 	"strconv"
+	"sync"
+	"time"
 )
 
+// defaultHeartbeatInterval StartHeartbeat 未指定间隔时使用的心跳间隔
+const defaultHeartbeatInterval = time.Minute
+
 // Heartbeat 构建并执行心跳 API 请求
 type Heartbeat struct {
 	client *Client
@@ -46,3 +51,38 @@ func (h *Heartbeat) Do(ctx ...context.Context) (bool, error) {
 	}
 	return true, nil
 }
+
+// StartHeartbeat 在后台按固定间隔发送心跳，保持会话活跃
+// interval 小于等于 0 时使用默认的 1 分钟；每次心跳失败时调用 onError（可为 nil），之后继续下一次心跳
+// ctx 取消或调用返回的 stop 后循环结束，stop 会等待正在进行的心跳返回，可重复调用
+func (c *Client) StartHeartbeat(ctx context.Context, interval time.Duration, onError func(error)) (stop func()) {
+	if interval <= 0 {
+		interval = defaultHeartbeatInterval
+	}
+	loopCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-loopCtx.Done():
+				return
+			case <-ticker.C:
+				if _, err := c.NewHeartbeat().Do(loopCtx); err != nil && onError != nil && loopCtx.Err() == nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			cancel()
+			<-done
+		})
+	}
+}
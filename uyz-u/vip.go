@@ -15,19 +15,27 @@ type VIPRequest struct {
 	Token string `json:"token"`
 }
 
+// VIPData 会员验证数据
+type VIPData struct {
+	Valid      bool   `json:"-"`          // 是否为有效会员（响应 code 为 0）
+	VipExpDate string `json:"vipExpDate"` // VIP到期日期
+	VipExpTime int    `json:"vipExpTime"` // VIP到期时间戳
+}
+
 // NewVIP 会员验证
 func (c *Client) NewVIP() *VIP {
 	return &VIP{client: c}
 }
 
 // Do 发送请求，可选择性地覆盖 context
-func (v *VIP) Do(ctx ...context.Context) (bool, error) {
+// 非会员不视为错误，返回 Valid 为 false 的数据
+func (v *VIP) Do(ctx ...context.Context) (VIPData, error) {
 	if v.client == nil {
-		return false, errNilClient
+		return VIPData{}, errNilClient
 	}
 	token, err := v.client.GetToken()
 	if err != nil {
-		return false, err
+		return VIPData{}, err
 	}
 	v.req.Token = token
 	var callCtx context.Context
@@ -42,6 +50,17 @@ func (v *VIP) Do(ctx ...context.Context) (bool, error) {
 	}
 
 	res, err := v.client.SecurePost(callCtx, "vip", v.req, nil)
-
-	return res.Code == 0, err
+	if err != nil {
+		return VIPData{}, err
+	}
+	// 非会员时 data 不是会员数据，不解析
+	if res.Code != 0 {
+		return VIPData{Valid: false}, nil
+	}
+	var payload VIPData
+	if err := v.client.DecryptResponse(res.Data, &payload); err != nil {
+		return VIPData{}, err
+	}
+	payload.Valid = true
+	return payload, nil
 }
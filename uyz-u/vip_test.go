@@ -0,0 +1,42 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVIPDo(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want VIPData
+	}{
+		// 非会员时 data 为提示文本而非JSON，不应返回解析错误
+		{"non-member", `{"code":201,"msg":"非会员","data":"会员已过期"}`, VIPData{}},
+		{"member", `{"code":0,"msg":"ok","data":"{\"vipExpDate\":\"2026-12-31\",\"vipExpTime\":1798646400}"}`,
+			VIPData{Valid: true, VipExpDate: "2026-12-31", VipExpTime: 1798646400}},
+	}
+
+	for _, c := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(c.body))
+		}))
+
+		client, err := New(ClientConfig{BaseURL: srv.URL, AppID: 1, DisableSignature: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.SetToken("token")
+
+		got, err := client.NewVIP().Do()
+		srv.Close()
+		if err != nil {
+			t.Fatalf("%s: Do error = %v", c.name, err)
+		}
+		if got != c.want {
+			t.Fatalf("%s: Do = %+v, want %+v", c.name, got, c.want)
+		}
+	}
+}